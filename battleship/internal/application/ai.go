@@ -1,11 +1,12 @@
 package application
 
 import (
-	"math/rand"
+	"SideProjectGames/internal/rng"
 )
 
 // TakeTurn implements the AI interface, generating a move based on a heatmap.
-func TakeTurn(board BattleshipBoard) (x, y int) {
+// Ties and the opening guess are broken with r.
+func TakeTurn(board BattleshipBoard, r rng.Source) (x, y int) {
 	// 1. Create a new heatmap for this turn.
 	heatMap := NewHeatmapBoard(board.Cols(), board.Rows())
 
@@ -36,15 +37,15 @@ func TakeTurn(board BattleshipBoard) (x, y int) {
 
 	// 5. If high-value targets are found, use it
 	if len(newBestCoords) > 0 {
-		choice := rand.Intn(len(newBestCoords))
+		choice := r.Intn(len(newBestCoords))
 		return newBestCoords[choice][0], newBestCoords[choice][1]
 	}
 
 	// 6. If no high-value targets are found (e.g., on the first turn),
 	// pick a random valid spot as a fallback.
 	for {
-		randX := rand.Intn(board.Cols())
-		randY := rand.Intn(board.Rows())
+		randX := r.Intn(board.Cols())
+		randY := r.Intn(board.Rows())
 		if board.Coordinate(randX, randY) == Empty {
 			return randX, randY
 		}
@@ -2,10 +2,9 @@ package application
 
 import (
 	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
 	"errors"
 	"fmt"
-	"math/rand"
-	"time"
 )
 
 const (
@@ -27,7 +26,7 @@ const (
 
 type BattleshipBoard interface {
 	ddd.Board[uint8]
-	SeedBoard()
+	SeedBoard(r rng.Source)
 	Attack(x, y int) (hit, sunk bool, shipType uint8, err error)
 	PlaceShip(x int, y int, shipType uint8, orientation uint8) bool
 	IsCellSunk(x, y int) bool
@@ -51,8 +50,11 @@ func NewBattleshipBoard(width int, height int) BattleshipBoard {
 	return newBattleshipBoard(width, height)
 }
 
+// newBattleshipBoard uses a bounded board; cells past the edges read as Miss so
+// placement and hunting logic treat the edge like a blocked cell.
 func newBattleshipBoard(width int, height int) BattleshipBoard {
-	return &battleshipBoard{Board: ddd.NewBoard[uint8](width, height), sunkShips: map[uint8]bool{
+	board := ddd.NewBoard[uint8](width, height, ddd.WithTopology[uint8](ddd.Bounded), ddd.WithOutside(Miss))
+	return &battleshipBoard{Board: board, sunkShips: map[uint8]bool{
 		Carrier:    false,
 		Battleship: false,
 		Cruiser:    false,
@@ -147,19 +149,13 @@ func (b *battleshipBoard) PlaceShip(x int, y int, shipType uint8, orientation ui
 	case Horizontal:
 		// Place to the right from (x, y)
 		for i := 0; i < length; i++ {
-			// bounds check to avoid panics on overflow
-			if x+i < b.Cols() && y < b.Rows() {
-				b.SetCoordinate(x+i, y, shipType)
-			}
+			b.SetCoordinate(x+i, y, shipType)
 		}
 
 	case Vertical:
 		// Place downward from (x, y)
 		for i := 0; i < length; i++ {
-			// bounds check to avoid panics on overflow
-			if x < b.Cols() && y+i < b.Rows() {
-				b.SetCoordinate(x, y+i, shipType)
-			}
+			b.SetCoordinate(x, y+i, shipType)
 		}
 	default:
 		fmt.Println("Unknown orientation: ", orientation)
@@ -170,7 +166,9 @@ func (b *battleshipBoard) PlaceShip(x int, y int, shipType uint8, orientation ui
 	return true
 }
 
-func (b *battleshipBoard) SeedBoard() {
+// SeedBoard clears the board and places every ship at random, drawing from r
+// so the same seed always gives the same fleet.
+func (b *battleshipBoard) SeedBoard(r rng.Source) {
 	// reset the board to empty
 	for x := 0; x < b.Cols(); x++ {
 		for y := 0; y < b.Rows(); y++ {
@@ -189,7 +187,6 @@ func (b *battleshipBoard) SeedBoard() {
 		}
 	}
 	// place ships randomly
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	shipTypes := []uint8{Carrier, Battleship, Cruiser, Submarine, Destroyer}
 	for _, shipType := range shipTypes {
 		placed := false
@@ -197,8 +194,8 @@ func (b *battleshipBoard) SeedBoard() {
 		for attempts := 0; attempts < 1000 && !placed; attempts++ {
 			orientation := uint8(r.Intn(2))
 			var x, y int
-			x = rand.Intn(b.Cols())
-			y = rand.Intn(b.Rows())
+			x = r.Intn(b.Cols())
+			y = r.Intn(b.Rows())
 			placed = b.PlaceShip(x, y, shipType, orientation)
 		}
 		if !placed {
@@ -207,20 +204,16 @@ func (b *battleshipBoard) SeedBoard() {
 	}
 }
 
+// CanPlace relies on the bounded board: cells past the edge read as Miss, so a
+// ship hanging off the board is rejected like one overlapping another ship.
 func (b *battleshipBoard) CanPlace(x, y, length int, orientation uint8) bool {
 	if orientation == Horizontal {
-		if x+length > b.Cols() {
-			return false
-		}
 		for i := 0; i < length; i++ {
 			if b.Coordinate(x+i, y) != Empty {
 				return false
 			}
 		}
 	} else if orientation == Vertical {
-		if y+length > b.Rows() {
-			return false
-		}
 		for i := 0; i < length; i++ {
 			if b.Coordinate(x, y+i) != Empty {
 				return false
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"SideProjectGames/internal/rng"
 	"sort"
 	"sync"
 	"testing"
@@ -90,10 +91,12 @@ func TestAttack_Sunk(t *testing.T) {
 }
 
 // onePlayerGame simulates a single game of Battleship for the AI and returns the number of moves taken to win.
-func onePlayerGame() int {
+// The fleet and every AI choice are drawn from seed.
+func onePlayerGame(seed int64) int {
+	random := rng.New(seed)
 	// The "solutionBoard" knows where the ships are. The AI will attack this board.
 	solutionBoard := NewBattleshipBoard(10, 10)
-	solutionBoard.SeedBoard()
+	solutionBoard.SeedBoard(random)
 
 	// The "aiViewBoard" is what the AI "sees". It only contains Empty, Hit, or Miss.
 	// The AI uses this board to make its decisions.
@@ -101,7 +104,7 @@ func onePlayerGame() int {
 
 	for moves := 1; moves <= ((solutionBoard.Cols() * solutionBoard.Rows()) * 2); moves++ {
 		// AI decides its move based on what it can see.
-		x, y := TakeTurn(aiViewBoard)
+		x, y := TakeTurn(aiViewBoard, random)
 
 		// The attack happens on the real board.
 		hit, sunk, shipType, err := solutionBoard.Attack(x, y)
@@ -171,11 +174,11 @@ func TestHeatMapAIAverage(t *testing.T) {
 	// Launch a goroutine for each game.
 	for i := 0; i < numGames; i++ {
 		wg.Add(1)
-		go func() {
+		go func(seed int64) {
 			defer wg.Done()
-			moves := onePlayerGame()
+			moves := onePlayerGame(seed)
 			resultsChan <- moves
-		}()
+		}(int64(i + 1))
 	}
 
 	// Wait for all games to finish, then close the channel.
@@ -226,13 +229,14 @@ func TestHeatMapAIResponseTime(t *testing.T) {
 	startTime := time.Now()
 
 	for i := 0; i < numGames; i++ {
+		random := rng.New(int64(i + 1))
 		solutionBoard := NewBattleshipBoard(10, 10)
-		solutionBoard.SeedBoard()
+		solutionBoard.SeedBoard(random)
 		aiViewBoard := NewBattleshipBoard(10, 10)
 
 		for moves := 1; moves <= solutionBoard.Cols()*solutionBoard.Rows(); moves++ {
 			aiMoveStart := time.Now()
-			x, y := TakeTurn(aiViewBoard)
+			x, y := TakeTurn(aiViewBoard, random)
 			aiMoveDuration := time.Since(aiMoveStart)
 			totalDuration += aiMoveDuration
 			totalMoves++
@@ -265,3 +269,20 @@ func TestHeatMapAIResponseTime(t *testing.T) {
 	t.Logf("Total AI moves: %d", totalMoves)
 	t.Logf("Average AI response time: %v", averageResponseTime)
 }
+
+func TestPlaceShip_RejectsOverhangWithoutWrapping(t *testing.T) {
+	board := newBattleshipBoard(10, 10)
+
+	if board.PlaceShip(8, 0, Carrier, Horizontal) {
+		t.Error("Expected a carrier hanging off the right edge to be rejected")
+	}
+	if board.PlaceShip(0, 9, Destroyer, Vertical) {
+		t.Error("Expected a destroyer hanging off the bottom edge to be rejected")
+	}
+	if coord := board.Coordinate(0, 0); coord != Empty {
+		t.Errorf("Expected rejected placements not to wrap onto (0, 0), but got %v", coord)
+	}
+	if coord := board.Coordinate(-1, 0); coord != Miss {
+		t.Errorf("Expected reads past the edge to return Miss, but got %v", coord)
+	}
+}
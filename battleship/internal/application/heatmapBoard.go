@@ -21,8 +21,10 @@ func NewHeatmapBoard(width, height int) HeatmapBoard {
 	return newHeatmapBoard(width, height)
 }
 
+// newHeatmapBoard uses a bounded board so heat never wraps around an edge;
+// cells past the edges read as zero heat.
 func newHeatmapBoard(width, height int) HeatmapBoard {
-	return &heatmapBoard{Board: ddd.NewBoard[int16](width, height)}
+	return &heatmapBoard{Board: ddd.NewBoard[int16](width, height, ddd.WithTopology[int16](ddd.Bounded))}
 }
 
 // CalculateHeatmap generates the probability map for the AI to make a decision.
@@ -71,8 +73,8 @@ func (hm *heatmapBoard) CalculateHeatmap(bsBoard BattleshipBoard) {
 	for r := 0; r < hm.Rows(); r++ {
 		for c := 0; c < hm.Cols(); c++ {
 			if bsBoard.Coordinate(c, r) == Empty {
-				isVerticalGap := (r > 0 && bsBoard.Coordinate(c, r-1) == Hit) && (r < hm.Rows()-1 && bsBoard.Coordinate(c, r+1) == Hit)
-				isHorizontalGap := (c > 0 && bsBoard.Coordinate(c-1, r) == Hit) && (c < hm.Cols()-1 && bsBoard.Coordinate(c+1, r) == Hit)
+				isVerticalGap := bsBoard.Coordinate(c, r-1) == Hit && bsBoard.Coordinate(c, r+1) == Hit
+				isHorizontalGap := bsBoard.Coordinate(c-1, r) == Hit && bsBoard.Coordinate(c+1, r) == Hit
 				bonus := int16(500)
 
 				if isVerticalGap || isHorizontalGap {
@@ -84,34 +86,35 @@ func (hm *heatmapBoard) CalculateHeatmap(bsBoard BattleshipBoard) {
 				// Check for adjacent hits to determine if we've found a line.
 				// Adds Extra Bonus in case of multiple Hits in a row
 				verticalBonus := int16(0)
-				if r > 0 && bsBoard.Coordinate(c, r-1) == Hit {
+				if bsBoard.Coordinate(c, r-1) == Hit {
 					verticalBonus += 500
 				}
-				if r < hm.Rows()-1 && bsBoard.Coordinate(c, r+1) == Hit {
+				if bsBoard.Coordinate(c, r+1) == Hit {
 					verticalBonus += 500
 				}
 
 				// Adds Extra Bonus in case of multiple Hits in a row
 				horizontalBonus := int16(0)
-				if c > 0 && bsBoard.Coordinate(c-1, r) == Hit {
+				if bsBoard.Coordinate(c-1, r) == Hit {
 					horizontalBonus += 500
 				}
-				if c < hm.Cols()-1 && bsBoard.Coordinate(c+1, r) == Hit {
+				if bsBoard.Coordinate(c+1, r) == Hit {
 					horizontalBonus += 500
 				}
 
+				// Neighbours past the edge read as Miss, so no bounds checks are needed.
 				// North neighbour
-				if r > 0 && bsBoard.Coordinate(c, r-1) == Empty {
+				if bsBoard.Coordinate(c, r-1) == Empty {
 					bonus := int16(100) + verticalBonus
 					hm.SetCoordinate(c, r-1, hm.Coordinate(c, r-1)+bonus)
 				}
 				// South neighbour
-				if r < hm.Rows()-1 && bsBoard.Coordinate(c, r+1) == Empty {
+				if bsBoard.Coordinate(c, r+1) == Empty {
 					bonus := int16(100) + verticalBonus
 					hm.SetCoordinate(c, r+1, hm.Coordinate(c, r+1)+bonus)
 				}
 				// West neighbour
-				if c > 0 && bsBoard.Coordinate(c-1, r) == Empty {
+				if bsBoard.Coordinate(c-1, r) == Empty {
 					bonus := int16(100) + horizontalBonus
 					if verticalBonus > 0 && horizontalBonus == 0 {
 						bonus = 0
@@ -119,7 +122,7 @@ func (hm *heatmapBoard) CalculateHeatmap(bsBoard BattleshipBoard) {
 					hm.SetCoordinate(c-1, r, hm.Coordinate(c-1, r)+bonus)
 				}
 				// East neighbour
-				if c < hm.Cols()-1 && bsBoard.Coordinate(c+1, r) == Empty {
+				if bsBoard.Coordinate(c+1, r) == Empty {
 					bonus := int16(100) + horizontalBonus
 					if verticalBonus > 0 && horizontalBonus == 0 {
 						bonus = 0
@@ -131,6 +134,8 @@ func (hm *heatmapBoard) CalculateHeatmap(bsBoard BattleshipBoard) {
 	}
 }
 
+// SumNeighbours adds up the heat of the 8 surrounding cells. Cells past the
+// edge of the bounded heatmap read as zero.
 func (hm *heatmapBoard) SumNeighbours(x, y int) int16 {
 	surroundArray := []int{-1, 0, 1}
 	totalSum := int16(0)
@@ -140,14 +145,6 @@ func (hm *heatmapBoard) SumNeighbours(x, y int) int16 {
 				continue
 			}
 
-			if x+xOffset < 0 || x+xOffset > hm.Cols()-1 {
-				continue
-			}
-
-			if y+yOffset < 0 || y+yOffset > hm.Rows()-1 {
-				continue
-			}
-
 			totalSum += hm.Coordinate(x+xOffset, y+yOffset)
 		}
 	}
@@ -178,12 +175,10 @@ func (hm *heatmapBoard) GetBestCoords(board BattleshipBoard) [][2]int {
 }
 
 // canPlaceShip is a helper to check if a ship can be placed without overlapping misses.
-// This is used for heatmap generation, not for initial board seeding.
+// This is used for heatmap generation, not for initial board seeding. Cells past
+// the edge of the bounded board read as Miss, which rules out overhanging ships.
 func canPlaceShip(board BattleshipBoard, x, y, length int, orientation uint8) bool {
 	if orientation == Horizontal {
-		if x+length > board.Cols() {
-			return false
-		}
 		for i := 0; i < length; i++ {
 			// A placement is invalid if it overlaps a Miss.
 			if board.Coordinate(x+i, y) != Empty {
@@ -191,9 +186,6 @@ func canPlaceShip(board BattleshipBoard, x, y, length int, orientation uint8) bo
 			}
 		}
 	} else { // Vertical
-		if y+length > board.Rows() {
-			return false
-		}
 		for i := 0; i < length; i++ {
 			if board.Coordinate(x, y+i) != Empty {
 				return false
@@ -1,6 +1,9 @@
 package application
 
-import "testing"
+import (
+	"SideProjectGames/internal/rng"
+	"testing"
+)
 
 func TestCalculateHeatmap_PopulatesOnEmptyViewBoard(t *testing.T) {
 	// Given an empty AI view board (no ships placed, no shots taken)
@@ -104,7 +107,7 @@ func TestTakeTurn_TiebreakerBySumSurrounding(t *testing.T) {
 	board.SetCoordinate(3, 0, Miss)
 
 	// 2. Act
-	x, y := TakeTurn(board)
+	x, y := TakeTurn(board, rng.New(1))
 
 	// 3. Assert
 	// This is where it should hit if it's working properly
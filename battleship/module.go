@@ -3,10 +3,12 @@ package battleship
 import (
 	"SideProjectGames/battleship/internal/application"
 	"SideProjectGames/internal/config"
+	"SideProjectGames/internal/module"
+	"SideProjectGames/internal/rng"
+	"SideProjectGames/internal/scene"
 	"bytes"
 	"fmt"
 	"image/color"
-	"log"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -20,9 +22,34 @@ var (
 	mplusFaceSource *text.GoTextFaceSource
 )
 
+func init() {
+	module.Register(battleshipModule{})
+}
+
+// battleshipModule exposes Battleship to the module registry.
+type battleshipModule struct{}
+
+func (battleshipModule) Name() string { return "BATTLESHIP" }
+
+func (battleshipModule) Description() string { return "Battleship against a heatmap AI" }
+
+func (battleshipModule) Run(cfg config.AppConfig) error { return Run(cfg) }
+
+func (battleshipModule) NewScene(cfg config.AppConfig) (scene.Scene, error) { return NewScene(cfg) }
+
 // Run launches an Ebiten window to visualize a Battleship game loop using the provided config.
 // This mirrors the Game of Life loop structure and prepares for separate User and AI boards.
 func Run(cfg config.AppConfig) error {
+	s, err := NewScene(cfg)
+	if err != nil {
+		return err
+	}
+	return scene.Run(s)
+}
+
+// NewScene builds a Battleship scene that can be hosted by a scene.Manager.
+func NewScene(cfg config.AppConfig) (scene.Scene, error) {
+	random := rng.New(cfg.SEED)
 	g := &game{
 		cellSize:        50,
 		stepEvery:       time.Millisecond * 100, // kept for consistency; not used yet for turn timing
@@ -32,28 +59,24 @@ func Run(cfg config.AppConfig) error {
 		userBoard:       application.NewBattleshipBoard(cfg.BATTLESHIPWIDTH, cfg.BATTLESHIPHEIGHT),
 		aiViewBoard:     application.NewBattleshipBoard(cfg.BATTLESHIPWIDTH, cfg.BATTLESHIPHEIGHT),
 		isPlayerTurn:    true,
+		seed:            cfg.SEED,
+		ai:              random.Derive("BATTLESHIP AI"),
 	}
 
-	s, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
-	if err != nil {
-		log.Fatal(err)
+	if mplusFaceSource == nil {
+		s, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
+		if err != nil {
+			return nil, err
+		}
+		mplusFaceSource = s
 	}
-	mplusFaceSource = s
 
-	g.userBoard.SeedBoard()
+	g.userBoard.SeedBoard(random.Derive("BATTLESHIP USER"))
 	g.userBoard.PrintBoard()
 
-	g.aiSolutionBoard.SeedBoard()
-	// Layout: two boards stacked vertically with a gap
-	gap := 20
-	boardW := g.cols * g.cellSize
-	boardH := g.rows * g.cellSize
-	w := boardW
-	h := boardH*2 + gap
-	ebiten.SetWindowSize(w, h)
-	ebiten.SetWindowTitle("Battleship")
+	g.aiSolutionBoard.SeedBoard(random.Derive("BATTLESHIP AI BOARD"))
 
-	return ebiten.RunGame(g)
+	return g, nil
 }
 
 type game struct {
@@ -67,6 +90,22 @@ type game struct {
 	isPlayerTurn    bool
 	gameOver        bool
 	winner          string
+	seed            int64
+	ai              rng.Source
+}
+
+var _ scene.Windowed = (*game)(nil)
+
+func (g *game) Title() string {
+	return "Battleship"
+}
+
+// WindowSize fits two boards stacked vertically with a gap.
+func (g *game) WindowSize() (int, int) {
+	gap := 20
+	boardW := g.cols * g.cellSize
+	boardH := g.rows * g.cellSize
+	return boardW, boardH*2 + gap
 }
 
 func (g *game) Update() error {
@@ -175,7 +214,7 @@ func (g *game) Draw(screen *ebiten.Image) {
 	}
 
 	// UI text
-	msg := fmt.Sprintf("User board (top) | AI board (bottom)   Cells: %dx%d  CellSize: %d", g.cols, g.rows, g.cellSize)
+	msg := fmt.Sprintf("User board (top) | AI board (bottom)   Cells: %dx%d  CellSize: %d  Seed: %d", g.cols, g.rows, g.cellSize, g.seed)
 	op := &text.DrawOptions{}
 	op.GeoM.Translate(10, float64(g.rows*g.cellSize*2+gap-28))
 	op.ColorScale.ScaleWithColor(color.RGBA{0, 0, 0, 255})
@@ -245,7 +284,7 @@ func (g *game) step() {
 		return
 	}
 	time.Sleep(500 * time.Millisecond) // Add a small delay for the AI's turn
-	x, y := application.TakeTurn(g.aiViewBoard)
+	x, y := application.TakeTurn(g.aiViewBoard, g.ai)
 	hit, sunk, shipType, err := g.aiSolutionBoard.Attack(x, y)
 
 	if err != nil {
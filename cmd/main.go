@@ -1,8 +1,9 @@
 package main
 
 import (
-	"SideProjectGames/battleship"
 	"SideProjectGames/internal/config"
+	"SideProjectGames/internal/menu"
+	"SideProjectGames/internal/module"
 	"fmt"
 	"os"
 )
@@ -19,12 +20,22 @@ func main() {
 func run() (err error) {
 	var cfg config.AppConfig
 
-	cfg, err = config.InitConfig()
+	cfg, err = config.InitConfig(module.Names()...)
 	if err != nil {
 		return err
 	}
 
-	if err := battleship.Run(cfg); err != nil {
+	// Without a MODULE the player picks a game from the main menu.
+	if cfg.MODULE == "" {
+		return menu.Run(cfg)
+	}
+
+	m, err := module.Lookup(cfg.MODULE)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Run(cfg); err != nil {
 		return err
 	}
 
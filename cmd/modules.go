@@ -0,0 +1,8 @@
+package main
+
+// Game modules register themselves with the module registry from their init
+// functions. Adding a new game only requires importing it here.
+import (
+	_ "SideProjectGames/battleship"
+	_ "SideProjectGames/gameoflife"
+)
@@ -0,0 +1,487 @@
+package gameoflife
+
+import (
+	"SideProjectGames/gameoflife/internal/camera"
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/multistate"
+	"SideProjectGames/gameoflife/internal/runstate"
+	"SideProjectGames/gameoflife/internal/tools"
+	"SideProjectGames/internal/config"
+	core "SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"SideProjectGames/internal/scene"
+	"fmt"
+	"image/color"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// The legend lists the states down the left of the window; clicking one
+	// picks it as the ink.
+	legendX        = 10
+	legendY        = 10
+	legendRow      = 24
+	legendSwatch   = 16
+	legendWidth    = 200
+	legendTextSize = 16
+	// maxLegendRows keeps rules with many dying states from filling the
+	// window; the states past it are still reached with Tab.
+	maxLegendRows = 16
+)
+
+// legendBackground sits behind the legend so it reads over any board.
+var legendBackground = color.RGBA{R: 20, G: 20, B: 20, A: 200}
+
+// automatonToolKeys binds keys to the editing tools of a multi-state board;
+// 5 is the cycle tool, which the toolbox does not know about.
+var automatonToolKeys = map[ebiten.Key]tools.Kind{
+	ebiten.Key1: tools.Brush,
+	ebiten.Key2: tools.Line,
+	ebiten.Key3: tools.Rectangle,
+	ebiten.Key4: tools.FilledRectangle,
+}
+
+// automaton is the scene for rules with more than two states, such as
+// Brian's Brain and Wireworld. It shares the camera, run state and editing
+// tools of the Game of Life scene, but draws every state in its own colour
+// and paints with a chosen state, the ink.
+type automaton struct {
+	read, write multistate.Board
+	rule        multistate.Rule
+	stepper     multistate.Stepper
+	palette     multistate.Palette
+	run         runstate.Machine
+	// seed is the generation R restores.
+	seed    []uint8
+	rngSeed int64
+	random  rng.Source
+	soup    ddd.SeedOptions
+
+	viewCols, viewRows int
+	cam                camera.Camera
+	screenW, screenH   int
+	drag               mouseDrag
+
+	tools      tools.Toolbox
+	toolButton ebiten.MouseButton
+	// cycling is set when the cycle tool is selected instead of a toolbox
+	// tool; ink is the state the left button paints.
+	cycling bool
+	ink     uint8
+	undo    core.UndoStack[uint8]
+	stroke  bool
+	message string
+	// snapshots holds, for each earlier generation with edits that can
+	// still be undone, the board as it was stepped on from, for undo to
+	// rewind to. edited is set once the current generation is edited.
+	snapshots map[int64][]uint8
+	edited    bool
+
+	// canvas holds the board one pixel per cell.
+	canvas *ebiten.Image
+	pixels []byte
+}
+
+var (
+	_ scene.Windowed  = (*automaton)(nil)
+	_ scene.Resizable = (*automaton)(nil)
+)
+
+// newAutomaton builds the scene for a multi-state rule on a fixed board.
+// Wireworld starts empty, ready for drawing circuits; Generations rules start
+// from a random soup like the Game of Life.
+func newAutomaton(cfg config.AppConfig, rule multistate.Rule, topology core.Topology, soup ddd.SeedOptions) (*automaton, error) {
+	if cfg.GOLWIDTH <= 0 || cfg.GOLHEIGHT <= 0 {
+		return nil, fmt.Errorf("board size %dx%d: expected a positive width and height", cfg.GOLWIDTH, cfg.GOLHEIGHT)
+	}
+	a := &automaton{
+		read:     multistate.NewBoard(cfg.GOLWIDTH, cfg.GOLHEIGHT, topology),
+		write:    multistate.NewBoard(cfg.GOLWIDTH, cfg.GOLHEIGHT, topology),
+		rule:     rule,
+		stepper:  multistate.NewStepper(rule),
+		palette:  multistate.DefaultPalette(rule),
+		run:      runstate.NewMachine(time.Millisecond * 100),
+		rngSeed:  cfg.SEED,
+		random:   rng.New(cfg.SEED).Derive("GOL"),
+		soup:     soup,
+		viewCols: cfg.GOLWIDTH,
+		viewRows: cfg.GOLHEIGHT,
+		cam:      camera.New(initialZoom),
+		ink:      1,
+		undo:     core.NewUndoStack[uint8](core.DefaultUndoLimit),
+
+		snapshots: make(map[int64][]uint8),
+	}
+	if _, ok := rule.(multistate.Wireworld); ok {
+		a.ink = multistate.Conductor
+	} else {
+		a.read.SeedBoard(a.random, a.soup)
+	}
+	a.saveSeed()
+	a.tools = tools.NewToolbox(a.paint)
+	a.tools.SetBounds(a.read.Cols(), a.read.Rows())
+	a.screenW, a.screenH = a.WindowSize()
+	a.fitView()
+	if err := loadFont(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *automaton) Title() string {
+	return "Cellular Automaton: " + a.rule.String()
+}
+
+func (a *automaton) WindowSize() (int, int) {
+	return min(a.viewCols*initialZoom, maxWindowWidth), min(a.viewRows*initialZoom, maxWindowHeight)
+}
+
+func (a *automaton) Resizable() bool {
+	return true
+}
+
+func (a *automaton) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if outsideWidth != a.screenW || outsideHeight != a.screenH {
+		a.cam.Resize(a.screenW, a.screenH, outsideWidth, outsideHeight)
+		a.screenW, a.screenH = outsideWidth, outsideHeight
+	}
+	return outsideWidth, outsideHeight
+}
+
+func (a *automaton) fitView() {
+	a.cam.Fit(0, 0, float64(a.read.Cols()), float64(a.read.Rows()), a.screenW, a.screenH)
+}
+
+func (a *automaton) Update() error {
+	// Edits from here on belong to the current generation.
+	a.undo.SetTag(a.run.Generation())
+	a.handleClick()
+	moveCamera(a.cam, a.screenW, a.screenH, &a.drag)
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		a.fitView()
+	}
+	a.handleKeys()
+	if a.run.Due(time.Now()) {
+		if a.edited {
+			// Due has already counted the step about to be taken.
+			a.snapshots[a.run.Generation()-1] = slices.Clone(a.read.FlatSlice())
+			a.edited = false
+		}
+		a.stepper.Step(a.read, a.write)
+		a.read, a.write = a.write, a.read
+		a.forgetSnapshots()
+	}
+	return nil
+}
+
+// maxSnapshotCells bounds the cells kept in snapshots for undo, 64 MB. Edits
+// from generations whose snapshot was dropped can no longer be undone.
+const maxSnapshotCells = 1 << 26
+
+// forgetSnapshots drops the snapshots no recorded edit can rewind to, then
+// the oldest ones while they hold more than maxSnapshotCells cells.
+func (a *automaton) forgetSnapshots() {
+	oldest, ok := a.undo.OldestTag()
+	for gen := range a.snapshots {
+		if !ok || gen < oldest {
+			delete(a.snapshots, gen)
+		}
+	}
+	keep := maxSnapshotCells / (a.read.Cols() * a.read.Rows())
+	for len(a.snapshots) > keep {
+		delete(a.snapshots, slices.Min(slices.Collect(maps.Keys(a.snapshots))))
+	}
+}
+
+// undoEdit reverts the last edit. As in the Game of Life, one made on an
+// earlier generation is undone there, after rewinding to its snapshot.
+func (a *automaton) undoEdit() {
+	gen, rewind := a.undo.Rewind()
+	if rewind {
+		board, ok := a.snapshots[gen]
+		if !ok {
+			a.undo.Clear()
+			a.message = fmt.Sprintf("Cannot undo: generation %d is no longer kept", gen)
+			return
+		}
+		a.read.CopyBoard(board)
+		a.run.JumpTo(gen)
+		a.undo.SetTag(gen)
+		// The generations after it are gone; its own snapshot is taken
+		// again when the edited board steps.
+		for later := range a.snapshots {
+			if later >= gen {
+				delete(a.snapshots, later)
+			}
+		}
+	}
+	if !a.undo.Undo(a.editor()) {
+		a.message = "Nothing to undo"
+		return
+	}
+	a.edited = true
+	a.message = "Undone"
+	if rewind {
+		a.message = fmt.Sprintf("Undone at generation %d", gen)
+	}
+}
+
+// handleKeys runs the run-state keys, Tab and Shift+Tab to change the ink,
+// and Ctrl+Z and Ctrl+Y (or Ctrl+Shift+Z) to undo and redo.
+func (a *automaton) handleKeys() {
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	if ctrlHeld() {
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyZ) && !shift:
+			a.undoEdit()
+		case inpututil.IsKeyJustPressed(ebiten.KeyY), inpututil.IsKeyJustPressed(ebiten.KeyZ):
+			a.message = "Nothing to redo"
+			if a.undo.Redo(a.editor()) {
+				a.edited = true
+				a.message = "Redone"
+			}
+		}
+		return
+	}
+
+	for key, action := range runKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			a.apply(a.run.Handle(action))
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		step := 1
+		if shift {
+			step = -1
+		}
+		a.ink = a.cycle(a.ink, step)
+	}
+}
+
+// cycle moves state by step through the rule's states, wrapping around.
+func (a *automaton) cycle(state uint8, step int) uint8 {
+	n := a.rule.States()
+	return uint8(((int(state)+step)%n + n) % n)
+}
+
+// apply carries out the board change a run-state action asked for.
+func (a *automaton) apply(effect runstate.Effect) {
+	switch effect {
+	case runstate.None:
+		return
+	case runstate.RestoreSeed:
+		a.read.CopyBoard(a.seed)
+	case runstate.ClearBoard:
+		a.read.CopyBoard(make([]uint8, a.read.Cols()*a.read.Rows()))
+	case runstate.NewSeed:
+		a.read.SeedBoard(a.random, a.soup)
+		a.saveSeed()
+	}
+	a.undo.Clear()
+	clear(a.snapshots)
+}
+
+// saveSeed remembers the current generation as the one R restores.
+func (a *automaton) saveSeed() {
+	a.seed = slices.Clone(a.read.FlatSlice())
+}
+
+// handleClick picks the ink from the legend, or feeds the mouse to the cycle
+// tool or the toolbox.
+func (a *automaton) handleClick() {
+	mouseX, mouseY := ebiten.CursorPosition()
+	x, y := a.cam.Cell(mouseX, mouseY)
+
+	if state, ok := a.legendHit(mouseX, mouseY); ok && !a.tools.Active() {
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			a.ink = state
+		}
+		return
+	}
+
+	switch {
+	case a.cycling:
+		// The cycle tool moves the clicked cell to its next state, or with
+		// the right button its previous one.
+		step := 0
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			step = 1
+		} else if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+			step = -1
+		}
+		if step != 0 && a.read.InBounds(x, y) {
+			core.Recorded(a.editor(), a.undo).SetCoordinate(x, y, a.cycle(a.read.Coordinate(x, y), step))
+			a.edited = true
+		}
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		a.beginStroke()
+		a.toolButton = ebiten.MouseButtonLeft
+		a.tools.Press(x, y, true)
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight):
+		a.beginStroke()
+		a.toolButton = ebiten.MouseButtonRight
+		a.tools.Press(x, y, false)
+	case a.tools.Active() && ebiten.IsMouseButtonPressed(a.toolButton):
+		a.tools.Drag(x, y)
+	case a.tools.Active():
+		a.tools.Release(x, y)
+	}
+
+	for key, tool := range automatonToolKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			a.tools.Select(tool)
+			a.cycling = false
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.Key5) {
+		a.tools.Select(tools.Brush)
+		a.cycling = true
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyComma) {
+		a.tools.SetBrushSize(a.tools.BrushSize() - 1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPeriod) {
+		a.tools.SetBrushSize(a.tools.BrushSize() + 1)
+	}
+	if a.stroke && !a.tools.Active() {
+		a.undo.End()
+		a.stroke = false
+	}
+}
+
+func (a *automaton) beginStroke() {
+	if !a.stroke {
+		a.undo.Begin()
+		a.stroke = true
+	}
+}
+
+// paint is the toolbox's way of editing the board: the left button paints
+// the ink and the right button empties cells. Cells off the board are
+// ignored.
+func (a *automaton) paint(x, y int, alive bool) {
+	var state uint8
+	if alive {
+		state = a.ink
+	}
+	core.Recorded(a.editor(), a.undo).SetCoordinate(x, y, state)
+	a.edited = true
+}
+
+// editor is the current generation with writes off the board refused rather
+// than wrapped.
+func (a *automaton) editor() core.Grid[uint8] {
+	return boundedGrid[uint8]{a.read}
+}
+
+// boundedGrid refuses writes past the edges of its board, whatever its
+// topology.
+type boundedGrid[T any] struct {
+	core.Board[T]
+}
+
+func (b boundedGrid[T]) SetCoordinate(x int, y int, value T) error {
+	if !b.InBounds(x, y) {
+		return core.ErrOutOfBounds
+	}
+	return b.Board.SetCoordinate(x, y, value)
+}
+
+// toolName is the HUD name of the selected tool.
+func (a *automaton) toolName() string {
+	if a.cycling {
+		return "Cycle"
+	}
+	return a.tools.Tool().String()
+}
+
+func (a *automaton) Draw(screen *ebiten.Image) {
+	screen.Fill(a.palette.Color(0))
+	a.drawBoard(screen)
+
+	x0, y0 := a.cam.WorldToScreen(0, 0)
+	x1, y1 := a.cam.WorldToScreen(float64(a.read.Cols()), float64(a.read.Rows()))
+	vector.StrokeRect(screen, float32(x0), float32(y0), float32(x1-x0), float32(y1-y0), 1, edge, false)
+
+	a.tools.Preview(func(x, y, width, height int) {
+		px, py := a.cam.WorldToScreen(float64(x), float64(y))
+		zoom := a.cam.Zoom()
+		vector.DrawFilledRect(screen, float32(px), float32(py), float32(max(float64(width)*zoom, 1)), float32(max(float64(height)*zoom, 1)), preview, false)
+	})
+
+	a.drawLegend(screen)
+
+	msg := fmt.Sprintf("Seed: %d  Rule: %s  Step Time: %v  %s  Gen: %d  Zoom: %.3gx  Tool: %s  Brush: %d  Ink: %s",
+		a.rngSeed, a.rule, a.run.Interval(), a.run.State(), a.run.Generation(), a.cam.Zoom(), a.toolName(), a.tools.BrushSize(), a.rule.StateName(a.ink))
+	if a.message != "" {
+		msg = a.message + "  " + msg
+	}
+	face := &text.GoTextFace{Source: mplusFaceSource, Size: 24}
+	textSize, _ := text.Measure(msg, face, 24)
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(a.screenW)-textSize, 10)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 0, 0, 255})
+	text.Draw(screen, msg, face, op)
+}
+
+// drawBoard paints the board one pixel per cell in its palette colours and
+// scales that up to the camera's zoom.
+func (a *automaton) drawBoard(screen *ebiten.Image) {
+	cols, rows := a.read.Cols(), a.read.Rows()
+	if a.canvas == nil || a.canvas.Bounds().Dx() != cols || a.canvas.Bounds().Dy() != rows {
+		a.canvas = ebiten.NewImage(cols, rows)
+		a.pixels = make([]byte, cols*rows*4)
+	}
+	for i, state := range a.read.FlatSlice() {
+		c := a.palette.Color(state)
+		a.pixels[i*4], a.pixels[i*4+1], a.pixels[i*4+2], a.pixels[i*4+3] = c.R, c.G, c.B, c.A
+	}
+	a.canvas.WritePixels(a.pixels)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(a.cam.Zoom(), a.cam.Zoom())
+	op.GeoM.Translate(a.cam.WorldToScreen(0, 0))
+	screen.DrawImage(a.canvas, op)
+}
+
+// legendRows is how many states the legend lists.
+func (a *automaton) legendRows() int {
+	return min(a.rule.States(), maxLegendRows)
+}
+
+// legendHit reports whether the point is on the legend and, if so, the state
+// of the row under it.
+func (a *automaton) legendHit(px, py int) (uint8, bool) {
+	if px < legendX || px >= legendX+legendWidth || py < legendY || py >= legendY+a.legendRows()*legendRow {
+		return 0, false
+	}
+	return uint8((py - legendY) / legendRow), true
+}
+
+// drawLegend lists each state with its colour, marking the ink.
+func (a *automaton) drawLegend(screen *ebiten.Image) {
+	rows := a.legendRows()
+	vector.DrawFilledRect(screen, legendX, legendY, legendWidth, float32(rows*legendRow), legendBackground, false)
+	face := &text.GoTextFace{Source: mplusFaceSource, Size: legendTextSize}
+	for i := 0; i < rows; i++ {
+		state := uint8(i)
+		y := float32(legendY + i*legendRow)
+		if state == a.ink {
+			vector.StrokeRect(screen, legendX+1, y+1, legendWidth-2, legendRow-2, 2, selected, false)
+		}
+		vector.DrawFilledRect(screen, legendX+4, y+4, legendSwatch, legendSwatch, a.palette.Color(state), false)
+		vector.StrokeRect(screen, legendX+4, y+4, legendSwatch, legendSwatch, 1, edge, false)
+
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(legendX+legendSwatch+12, float64(y)+2)
+		op.ColorScale.ScaleWithColor(white)
+		text.Draw(screen, fmt.Sprintf("%d %s", i, a.rule.StateName(state)), face, op)
+	}
+}
@@ -0,0 +1,124 @@
+package gameoflife
+
+import (
+	"SideProjectGames/gameoflife/internal/engine"
+	"SideProjectGames/gameoflife/internal/export"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// handleCapture saves the current generation as a PNG with P and starts or
+// stops recording a GIF with G.
+func (g *game) handleCapture() {
+	if ctrlHeld() {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.snapshot()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		if g.recording == nil {
+			g.startRecording()
+		} else {
+			g.stopRecording()
+		}
+	}
+}
+
+// snapshot writes the whole board, or when unbounded the live cells, as a PNG
+// of at most export.DefaultMaxPixels pixels.
+func (g *game) snapshot() {
+	var x, y, width, height int
+	if g.unbounded() {
+		minX, minY, maxX, maxY, ok := g.sparseRead.Bounds()
+		if !ok {
+			g.message = "Nothing to export"
+			return
+		}
+		x, y, width, height = minX, minY, maxX-minX+1, maxY-minY+1
+	} else {
+		width, height = g.read.Cols(), g.read.Rows()
+	}
+	if !g.exportStyle.Fits(width, height, export.DefaultMaxPixels) {
+		g.message = "Board too large to export at this cell size"
+		return
+	}
+	var img image.Image
+	if g.unbounded() {
+		img = export.Render(g.sparseRead, x, y, width, height, g.exportStyle)
+	} else {
+		img = export.RenderBoard(g.read, g.exportStyle)
+	}
+	g.saveExport("png", func(w io.Writer) error { return export.WritePNG(w, img) })
+}
+
+// startRecording records the current generation and every one after it, up
+// to export.DefaultMaxFrames or the recorder's pixel and cell budgets, for a
+// GIF. A fixed board is recorded whole; an unbounded one is cropped to the
+// cells that come alive.
+func (g *game) startRecording() {
+	opts := export.DefaultRecordOptions
+	opts.Style = g.exportStyle
+	opts.Delay = g.run.Interval()
+	if !g.unbounded() {
+		opts.Crop = false
+		opts.Width, opts.Height = g.read.Cols(), g.read.Rows()
+	}
+	g.recording = export.NewRecorder(opts)
+	g.record()
+}
+
+// record adds the current generation to the recording, finishing it once full.
+func (g *game) record() {
+	if g.recording == nil {
+		return
+	}
+	if g.unbounded() {
+		g.recording.Add(engine.SparseLiveCells(g.sparseRead))
+	} else {
+		g.recording.Add(engine.LiveCells(g.read))
+	}
+	if g.recording.Len() == 0 {
+		// Even one frame is over the budget; there is nothing to save.
+		g.recording = nil
+		g.message = "Board too large to record at this cell size"
+		return
+	}
+	g.message = fmt.Sprintf("Recording GIF: %d/%d frames (G to stop)", g.recording.Len(), g.recording.Limit())
+	if g.recording.Full() {
+		g.stopRecording()
+	}
+}
+
+// stopRecording writes the recording as a GIF.
+func (g *game) stopRecording() {
+	recording := g.recording
+	g.recording = nil
+	g.saveExport("gif", recording.WriteGIF)
+}
+
+// saveExport writes a file named after the time and generation into the
+// export directory and reports where it went.
+func (g *game) saveExport(ext string, write func(io.Writer) error) {
+	name := fmt.Sprintf("gol-%s-gen%d.%s", time.Now().Format("20060102-150405"), g.run.Generation(), ext)
+	path := filepath.Join(g.exportDir, name)
+	f, err := os.Create(path)
+	if err == nil {
+		err = write(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		g.message = fmt.Sprintf("Export failed: %v", err)
+		return
+	}
+	g.message = "Saved " + path
+}
@@ -0,0 +1,97 @@
+// Command census runs random Game of Life soups until they stabilize and
+// writes a frequency table of the objects they leave behind, by canonical
+// apgcode, as CSV or JSON.
+//
+//	go run ./gameoflife/cmd/census -soups 10000 -seed 7 -out census.csv
+package main
+
+import (
+	"SideProjectGames/gameoflife/internal/census"
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	opts := census.DefaultOptions
+	flags := flag.NewFlagSet("census", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.IntVar(&opts.Soups, "soups", opts.Soups, "number of soups to run")
+	flags.IntVar(&opts.Width, "width", opts.Width, "soup width in cells")
+	flags.IntVar(&opts.Height, "height", opts.Height, "soup height in cells")
+	flags.Float64Var(&opts.Density, "density", opts.Density, "chance of each soup cell starting alive")
+	symmetry := flags.String("symmetry", "NONE", "soup symmetry: NONE, C2, C4 or D8")
+	rule := flags.String("rule", "B3/S23", "Life-like rule in B/S or S/B notation, or by name")
+	flags.Int64Var(&opts.Seed, "seed", opts.Seed, "base seed; soup i uses seed+i")
+	flags.IntVar(&opts.Workers, "workers", 0, "soups run at once (0 means one per CPU)")
+	flags.IntVar(&opts.MaxGenerations, "max-gens", opts.MaxGenerations, "generations before a soup is given up on")
+	flags.IntVar(&opts.MaxPeriod, "max-period", opts.MaxPeriod, "longest oscillator or spaceship period recognized")
+	format := flags.String("format", "", "csv or json (default: from the -out extension, else csv)")
+	out := flags.String("out", "", "output file (default: standard output)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var err error
+	if opts.Rule, err = ddd.ParseRule(*rule); err != nil {
+		return err
+	}
+	if opts.Rule.Born(0) {
+		// Soups run on an unbounded board.
+		return fmt.Errorf("census: %s: %w", opts.Rule, engine.ErrBirthOnZero)
+	}
+	if opts.Symmetry, err = ddd.ParseSymmetry(*symmetry); err != nil {
+		return err
+	}
+	switch {
+	case opts.Soups <= 0, opts.Width <= 0, opts.Height <= 0:
+		return fmt.Errorf("census: -soups, -width and -height must be positive")
+	case opts.Density < 0 || opts.Density > 1:
+		return fmt.Errorf("census: -density must be between 0 and 1")
+	case opts.MaxPeriod <= 0 || opts.MaxGenerations <= 0:
+		return fmt.Errorf("census: -max-gens and -max-period must be positive")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*out), ".")
+	}
+	write := census.Result.WriteCSV
+	switch strings.ToLower(*format) {
+	case "", "csv":
+	case "json":
+		write = census.Result.WriteJSON
+	default:
+		return fmt.Errorf("census: unknown format %q; expected csv or json", *format)
+	}
+
+	start := time.Now()
+	result := census.Run(opts, func(done int) {
+		if done%100 == 0 || done == opts.Soups {
+			fmt.Fprintf(stderr, "\r%d/%d soups", done, opts.Soups)
+		}
+	})
+	fmt.Fprintf(stderr, "\r%d soups in %v, %d did not stabilize\n", opts.Soups, time.Since(start).Round(time.Millisecond), result.Unstabilized)
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return write(result, w)
+}
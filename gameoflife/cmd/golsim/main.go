@@ -0,0 +1,488 @@
+// Command golsim runs Game of Life without a window, for batch experiments
+// and CI. It loads a pattern or draws a random soup, runs it for a number of
+// generations or until it settles, prints population statistics and writes
+// the final board as RLE, plaintext, Life 1.06 or PNG. It can also record the
+// run as an animated GIF or APNG.
+//
+//	go run ./gameoflife/cmd/golsim -pattern glider.rle -edge INFINITE -gens 1000 -stable -out final.rle
+//	go run ./gameoflife/cmd/golsim -seed 7 -width 64 -height 64 -gens 200 -gif run.gif -grid
+//
+// It exits 0 on success, 1 on a runtime error, 2 on bad flags and 3 when
+// -stable was given and the board had not settled by -gens generations.
+package main
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"SideProjectGames/gameoflife/internal/export"
+	"SideProjectGames/gameoflife/internal/hashlife"
+	core "SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Exit codes.
+const (
+	exitOK        = 0
+	exitError     = 1
+	exitUsage     = 2
+	exitUnsettled = 3
+)
+
+// errUnsettled is returned when -stable was asked for and the board was still
+// evolving after -gens generations.
+var errUnsettled = errors.New("golsim: the board did not settle")
+
+// usageError marks errors in the command line, which exit with exitUsage.
+type usageError struct{ error }
+
+func main() {
+	os.Exit(exitCode(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr), os.Stderr))
+}
+
+// exitCode reports err on stderr and maps it to the process exit code.
+func exitCode(err error, stderr io.Writer) int {
+	var usage usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.Is(err, errUnsettled):
+		fmt.Fprintln(stderr, err.Error())
+		return exitUnsettled
+	case errors.As(err, &usage):
+		fmt.Fprintln(stderr, err.Error())
+		return exitUsage
+	}
+	fmt.Fprintln(stderr, err.Error())
+	return exitError
+}
+
+// options are the parsed command line.
+type options struct {
+	pattern   string
+	width     int
+	height    int
+	edge      string
+	engine    string
+	board     string
+	stepper   string
+	workers   int
+	rule      string
+	seed      int64
+	density   float64
+	region    string
+	symmetry  string
+	gens      int
+	stable    bool
+	history   int
+	out       string
+	format    string
+	cellSize  int
+	grid      bool
+	gif       string
+	apng      string
+	delay     time.Duration
+	maxFrames int
+	crop      bool
+	seedGiven bool
+}
+
+func parse(args []string, stderr io.Writer) (options, error) {
+	var o options
+	flags := flag.NewFlagSet("golsim", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&o.pattern, "pattern", "", "pattern file (RLE, plaintext or Life 1.06) to start from, or - for standard input; default: a random soup")
+	flags.IntVar(&o.width, "width", 80, "board width in cells (the soup width when -edge is INFINITE)")
+	flags.IntVar(&o.height, "height", 60, "board height in cells (the soup height when -edge is INFINITE)")
+	flags.StringVar(&o.edge, "edge", "TOROIDAL", "edge topology: TOROIDAL, BOUNDED, KLEIN, PROJECTIVE or INFINITE")
+	flags.StringVar(&o.engine, "engine", "SPARSE", "unbounded engine with -edge INFINITE: SPARSE or HASHLIFE (jumps straight to -gens when nothing watches every generation)")
+	flags.StringVar(&o.board, "board", "BOOL", "fixed board storage: BOOL or BITS")
+	flags.StringVar(&o.stepper, "stepper", "ACTIVE", "fixed board stepper: ACTIVE, PARALLEL, BITWISE or SERIAL")
+	flags.IntVar(&o.workers, "workers", 0, "workers for the parallel and bitwise steppers (0 means one per CPU)")
+	flags.StringVar(&o.rule, "rule", "", "Life-like rule in B/S or S/B notation, or by name (default: the pattern's rule, else B3/S23)")
+	flags.Int64Var(&o.seed, "seed", 0, "soup seed (default: picked from the clock and printed)")
+	flags.Float64Var(&o.density, "density", ddd.DefaultSeedOptions.Density, "chance of each soup cell starting alive")
+	flags.StringVar(&o.region, "region", "", "soup region as x,y,width,height (default: the whole board)")
+	flags.StringVar(&o.symmetry, "symmetry", "NONE", "soup symmetry: NONE, C2, C4 or D8")
+	flags.IntVar(&o.gens, "gens", 1000, "generations to run, or the most to run with -stable")
+	flags.BoolVar(&o.stable, "stable", false, "stop as soon as the board settles; exit 3 if it has not by -gens")
+	flags.IntVar(&o.history, "history", engine.DefaultHistory, "longest period -stable recognizes")
+	flags.StringVar(&o.out, "out", "", "file for the final board, or - for standard output (default: not written)")
+	flags.StringVar(&o.format, "format", "", "rle, cells, lif or png (default: from the -out extension, else rle)")
+	flags.IntVar(&o.cellSize, "cell-size", export.DefaultStyle.CellSize, "PNG, GIF and APNG pixels per cell")
+	// -scale was the name of -cell-size before recordings were added.
+	flags.IntVar(&o.cellSize, "scale", export.DefaultStyle.CellSize, "deprecated: use -cell-size")
+	flags.BoolVar(&o.grid, "grid", false, "draw grid lines between cells in images")
+	flags.StringVar(&o.gif, "gif", "", "record every generation to this animated GIF")
+	flags.StringVar(&o.apng, "apng", "", "record every generation to this animated PNG")
+	flags.DurationVar(&o.delay, "delay", export.DefaultRecordOptions.Delay, "time each recorded frame is shown")
+	flags.IntVar(&o.maxFrames, "max-frames", export.DefaultMaxFrames, "most generations recorded")
+	flags.BoolVar(&o.crop, "crop", false, "crop recordings to the live cells (always on when -edge is INFINITE)")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return o, err
+		}
+		return o, usageError{err}
+	}
+	if flags.NArg() > 0 {
+		return o, usageError{fmt.Errorf("golsim: unexpected arguments %q", flags.Args())}
+	}
+	flags.Visit(func(f *flag.Flag) { o.seedGiven = o.seedGiven || f.Name == "seed" })
+
+	switch {
+	case o.width <= 0 || o.height <= 0:
+		return o, usageError{errors.New("golsim: -width and -height must be positive")}
+	case o.gens < 0:
+		return o, usageError{errors.New("golsim: -gens must not be negative")}
+	case o.history <= 0:
+		return o, usageError{errors.New("golsim: -history must be positive")}
+	case o.density < 0 || o.density > 1:
+		return o, usageError{errors.New("golsim: -density must be between 0 and 1")}
+	case o.cellSize <= 0:
+		return o, usageError{errors.New("golsim: -cell-size must be positive")}
+	case o.maxFrames <= 0:
+		return o, usageError{errors.New("golsim: -max-frames must be positive")}
+	}
+	switch o.engine = strings.ToUpper(strings.TrimSpace(o.engine)); {
+	case o.engine != "SPARSE" && o.engine != "HASHLIFE":
+		return o, usageError{fmt.Errorf("golsim: unknown engine %q; expected SPARSE or HASHLIFE", o.engine)}
+	case o.engine == "HASHLIFE" && !ddd.IsUnbounded(o.edge):
+		return o, usageError{errors.New("golsim: -engine HASHLIFE needs -edge INFINITE")}
+	}
+	if o.format == "" && o.out != "-" {
+		o.format = strings.TrimPrefix(filepath.Ext(o.out), ".")
+	}
+	o.format = strings.ToLower(o.format)
+	switch o.format {
+	case "", "rle", "cells", "txt", "lif", "life", "png":
+	default:
+		return o, usageError{fmt.Errorf("golsim: unknown format %q; expected rle, cells, lif or png", o.format)}
+	}
+	return o, nil
+}
+
+// universe is the engine being run and the fixed board behind it, if any.
+type universe struct {
+	engine.Engine
+	board ddd.GolBoard
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	o, err := parse(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	var pattern *ddd.Pattern
+	if o.pattern != "" {
+		if pattern, err = readPattern(o.pattern, stdin); err != nil {
+			return err
+		}
+	}
+	ruleString := o.rule
+	if ruleString == "" && pattern != nil {
+		ruleString = pattern.Rule
+	}
+	rule, err := ddd.ParseRule(ruleString)
+	if err != nil {
+		return usageError{err}
+	}
+	soup := ddd.SeedOptions{Density: o.density}
+	if soup.X, soup.Y, soup.Width, soup.Height, err = ddd.ParseRegion(o.region); err != nil {
+		return usageError{err}
+	}
+	if soup.Symmetry, err = ddd.ParseSymmetry(o.symmetry); err != nil {
+		return usageError{err}
+	}
+	if !o.seedGiven {
+		o.seed = rng.NewSeed()
+	}
+
+	u, err := build(o, rule, pattern, soup)
+	if err != nil {
+		return err
+	}
+
+	// Statistics go to stdout, unless the board does.
+	report := stdout
+	if o.out == "-" {
+		report = stderr
+	}
+	fmt.Fprintf(report, "rule:        %s\n", rule)
+	fmt.Fprintf(report, "edge:        %s\n", strings.ToUpper(o.edge))
+	if pattern == nil {
+		fmt.Fprintf(report, "seed:        %d\n", o.seed)
+	}
+
+	var recorder export.Recorder
+	if o.gif != "" || o.apng != "" {
+		recorder = export.NewRecorder(recordOptions(u, o))
+	}
+	stats, verdict := simulate(u, o, recorder)
+	stats.write(report)
+	if o.stable || verdict.Settled() {
+		fmt.Fprintf(report, "behaviour:   %s\n", verdict)
+	}
+
+	if o.out != "" {
+		if err := writeBoard(u, rule, o, stdout); err != nil {
+			return err
+		}
+	}
+	if o.gif != "" {
+		if err := writeFile(o.gif, recorder.WriteGIF); err != nil {
+			return err
+		}
+	}
+	if o.apng != "" {
+		if err := writeFile(o.apng, recorder.WriteAPNG); err != nil {
+			return err
+		}
+	}
+	if o.stable && !verdict.Settled() {
+		return errUnsettled
+	}
+	return nil
+}
+
+// readPattern reads the pattern at path, or from stdin when path is "-".
+func readPattern(path string, stdin io.Reader) (*ddd.Pattern, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	p, _, err := ddd.ReadPattern(path, r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// build sets up the universe the way the game does: a pattern is centred on
+// a fixed board or placed at the origin of an unbounded one, and a soup fills
+// the seeding region.
+func build(o options, rule ddd.Rule, pattern *ddd.Pattern, soup ddd.SeedOptions) (universe, error) {
+	random := rng.New(o.seed).Derive("GOL")
+	if ddd.IsUnbounded(o.edge) {
+		if rule.Born(0) {
+			return universe{}, usageError{fmt.Errorf("golsim: %s: %w", rule, engine.ErrBirthOnZero)}
+		}
+		b := ddd.NewSparseBoard()
+		if pattern != nil {
+			pattern.Place(b, 0, 0)
+		} else {
+			if soup.Width <= 0 || soup.Height <= 0 {
+				soup.X, soup.Y, soup.Width, soup.Height = 0, 0, o.width, o.height
+			}
+			b.SeedRegion(random, soup)
+		}
+		if o.engine == "HASHLIFE" {
+			h, err := hashlife.New(rule)
+			if err != nil {
+				return universe{}, usageError{err}
+			}
+			b.EachLive(func(x, y int) { h.Set(int64(x), int64(y), true) })
+			return universe{Engine: h}, nil
+		}
+		return universe{Engine: engine.NewSparseEngine(b, rule)}, nil
+	}
+
+	topology, err := core.ParseTopology(o.edge)
+	if err != nil {
+		return universe{}, usageError{err}
+	}
+	stepper, err := engine.NewStepper(o.stepper, rule, o.workers)
+	if err != nil {
+		return universe{}, usageError{err}
+	}
+	board, err := ddd.NewBoardOfKind(o.board, o.width, o.height, topology)
+	if err != nil {
+		return universe{}, usageError{err}
+	}
+	scratch, _ := ddd.NewBoardOfKind(o.board, o.width, o.height, topology)
+	if pattern != nil {
+		if err := pattern.PlaceCentered(board); err != nil {
+			return universe{}, fmt.Errorf("%s: %w", o.pattern, err)
+		}
+	} else {
+		board.SeedBoard(random, soup)
+	}
+	return universe{Engine: engine.NewBoardEngine(board, scratch, stepper), board: board}, nil
+}
+
+// stats summarize the population over a run.
+type stats struct {
+	generations int64
+	initial     int64
+	final       int64
+	min, max    int64
+	minGen      int64
+	maxGen      int64
+	sum         int64
+	// jumped is set when the run skipped the generations in between, so
+	// only the first and last were seen.
+	jumped bool
+}
+
+func (s *stats) observe(gen, population int64) {
+	if gen == 0 {
+		s.initial, s.min, s.max = population, population, population
+	}
+	if population < s.min {
+		s.min, s.minGen = population, gen
+	}
+	if population > s.max {
+		s.max, s.maxGen = population, gen
+	}
+	s.generations, s.final = gen, population
+	s.sum += population
+}
+
+func (s stats) write(w io.Writer) {
+	fmt.Fprintf(w, "generations: %d\n", s.generations)
+	fmt.Fprintf(w, "population:  initial %d, final %d\n", s.initial, s.final)
+	if s.jumped {
+		return
+	}
+	fmt.Fprintf(w, "min:         %d at generation %d\n", s.min, s.minGen)
+	fmt.Fprintf(w, "max:         %d at generation %d\n", s.max, s.maxGen)
+	fmt.Fprintf(w, "mean:        %.2f\n", float64(s.sum)/float64(s.generations+1))
+}
+
+// simulate runs u for o.gens generations, or with o.stable until the cycle
+// detector sees it repeat, handing each generation to recorder if there is
+// one. The verdict is only meaningful with o.stable. HashLife jumps straight
+// to the last generation when nothing needs the ones in between; the
+// statistics then only cover the first and last.
+func simulate(u universe, o options, recorder export.Recorder) (stats, engine.Verdict) {
+	var s stats
+	var d engine.CycleDetector
+	var v engine.Verdict
+	if _, ok := u.Engine.(*hashlife.Universe); ok && !o.stable && recorder == nil {
+		s.observe(u.Generation(), u.Population())
+		u.Step(int64(o.gens))
+		s.observe(u.Generation(), u.Population())
+		s.jumped = true
+		return s, v
+	}
+	if o.stable {
+		d = engine.NewCycleDetector(o.history)
+	}
+	observe := func(gen int) {
+		s.observe(u.Generation(), u.Population())
+		if d == nil && (recorder == nil || recorder.Full()) {
+			return
+		}
+		cells := u.liveCells()
+		if d != nil {
+			v = d.Observe(gen, cells)
+		}
+		if recorder != nil {
+			recorder.Add(cells)
+		}
+	}
+	observe(0)
+	for gen := 1; gen <= o.gens && !v.Settled(); gen++ {
+		u.Step(1)
+		observe(gen)
+	}
+	return s, v
+}
+
+// liveCells lists the live cells of the current generation.
+func (u universe) liveCells() []engine.Cell {
+	if u.board != nil {
+		return engine.LiveCells(u.board)
+	}
+	return engine.EngineLiveCells(u)
+}
+
+func (o options) style() export.Style {
+	style := export.DefaultStyle
+	style.CellSize, style.Grid = o.cellSize, o.grid
+	return style
+}
+
+// recordOptions show the whole of a fixed board unless -crop is given; an
+// unbounded one is always cropped to its live cells.
+func recordOptions(u universe, o options) export.RecordOptions {
+	opts := export.RecordOptions{Style: o.style(), Delay: o.delay, MaxFrames: o.maxFrames, Crop: o.crop}
+	if u.board != nil {
+		opts.Width, opts.Height = u.board.Cols(), u.board.Rows()
+	}
+	return opts
+}
+
+// writeFile creates path and writes it with write.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// finalPattern is the whole board for a fixed board, or the bounding box of
+// the live cells for an unbounded one. It refuses a bounding box of more
+// than ddd.MaxPatternCells cells, which no pattern reader would load back.
+func finalPattern(u universe, rule ddd.Rule) (*ddd.Pattern, error) {
+	var p *ddd.Pattern
+	if u.board != nil {
+		p = ddd.PatternFromBoard(u.board, 0, 0, u.board.Cols(), u.board.Rows())
+	} else if minX, minY, maxX, maxY, ok := u.Bounds(); ok {
+		width, height := maxX-minX+1, maxY-minY+1
+		if width > ddd.MaxPatternCells/height {
+			return nil, fmt.Errorf("the live cells span %dx%d, more than the %d cells a pattern can hold", width, height, ddd.MaxPatternCells)
+		}
+		p = ddd.NewPattern(int(width), int(height))
+		for _, c := range engine.EngineLiveCells(u) {
+			p.Set(c.X-int(minX), c.Y-int(minY), true)
+		}
+	} else {
+		p = ddd.NewPattern(0, 0)
+	}
+	p.Rule = rule.String()
+	p.Comments = []string{fmt.Sprintf("Generation %d", u.Generation())}
+	return p, nil
+}
+
+func writeBoard(u universe, rule ddd.Rule, o options, stdout io.Writer) error {
+	p, err := finalPattern(u, rule)
+	if err != nil {
+		return err
+	}
+	write := func(w io.Writer) error {
+		switch o.format {
+		case "png":
+			return export.WritePNG(w, export.RenderPattern(p, o.style()))
+		case "cells", "txt":
+			return ddd.WritePattern(w, p, ddd.FormatPlaintext)
+		case "lif", "life":
+			return ddd.WritePattern(w, p, ddd.FormatLife106)
+		}
+		return ddd.WritePattern(w, p, ddd.FormatRLE)
+	}
+	if o.format == "png" && !o.style().Fits(p.Width, p.Height, export.DefaultMaxPixels) {
+		return fmt.Errorf("a %dx%d board at -cell-size %d is over the %d pixel PNG limit", p.Width, p.Height, o.cellSize, export.DefaultMaxPixels)
+	}
+	if o.out == "-" {
+		return write(stdout)
+	}
+	return writeFile(o.out, write)
+}
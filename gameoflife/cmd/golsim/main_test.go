@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const glider = "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"
+
+func golsim(stdin string, args ...string) (code int, stdout string) {
+	var out, errs bytes.Buffer
+	code = exitCode(run(args, strings.NewReader(stdin), &out, &errs), io.Discard)
+	return code, out.String()
+}
+
+func TestRun_GliderSettles(t *testing.T) {
+	code, out := golsim(glider, "-pattern", "-", "-edge", "INFINITE", "-stable")
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d", exitOK, code)
+	}
+	for _, want := range []string{"generations: 4", "initial 5, final 5", "spaceship with displacement (1, 1) every 4 generations"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected the report to contain %q, but got\n%s", want, out)
+		}
+	}
+}
+
+func TestRun_WritesTheFinalBoard(t *testing.T) {
+	code, out := golsim(glider, "-pattern", "-", "-edge", "INFINITE", "-gens", "2", "-out", "-", "-format", "cells")
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d", exitOK, code)
+	}
+	if want := "..O\nO.O\n.OO\n"; !strings.HasSuffix(out, want) {
+		t.Errorf("Expected the glider two generations on\n%s\nbut got\n%s", want, out)
+	}
+}
+
+func TestRun_WritesTheFinalBoardToAFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "final.cells")
+	code, _ := golsim(glider, "-pattern", "-", "-edge", "INFINITE", "-gens", "2", "-out", path)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d", exitOK, code)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the board to be written, but got %v", err)
+	}
+	if want := "..O\nO.O\n.OO\n"; !strings.HasSuffix(string(data), want) {
+		t.Errorf("Expected the glider two generations on\n%s\nbut got\n%s", want, data)
+	}
+}
+
+func TestRun_HashLifeMatchesSparse(t *testing.T) {
+	args := []string{"-pattern", "-", "-edge", "INFINITE", "-gens", "1000", "-out", "-"}
+	_, sparse := golsim(glider, args...)
+	code, hashed := golsim(glider, append(args, "-engine", "hashlife")...)
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d", exitOK, code)
+	}
+	if hashed != sparse {
+		t.Errorf("Expected HashLife to end on the same board as the sparse engine\n%s\nbut got\n%s", sparse, hashed)
+	}
+}
+
+func TestRun_RecordsGIF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.gif")
+	code, _ := golsim(glider, "-pattern", "-", "-edge", "INFINITE", "-gens", "8", "-gif", path, "-cell-size", "2")
+	if code != exitOK {
+		t.Fatalf("Expected exit code %d, but got %d", exitOK, code)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected the GIF to be written, but got %v", err)
+	}
+	defer f.Close()
+	g, err := gif.DecodeAll(f)
+	if err != nil {
+		t.Fatalf("Expected a readable GIF, but got %v", err)
+	}
+	// Generations 0 to 8, cropped to the 5x5 box the glider sweeps.
+	if len(g.Image) != 9 || g.Config.Width != 10 || g.Config.Height != 10 {
+		t.Errorf("Expected 9 frames of 10x10 pixels, but got %d of %dx%d", len(g.Image), g.Config.Width, g.Config.Height)
+	}
+}
+
+func TestRun_IsReproducible(t *testing.T) {
+	_, a := golsim("", "-seed", "9", "-width", "20", "-height", "20", "-gens", "50", "-out", "-")
+	_, b := golsim("", "-seed", "9", "-width", "20", "-height", "20", "-gens", "50", "-out", "-")
+	if a != b {
+		t.Errorf("Expected the same seed to give the same board, but got\n%s\nand\n%s", a, b)
+	}
+}
+
+func TestRun_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"unsettled", []string{"-seed", "3", "-gens", "5", "-stable"}, exitUnsettled},
+		{"bad flag", []string{"-gens", "many"}, exitUsage},
+		{"bad format", []string{"-format", "gif"}, exitUsage},
+		{"bad rule", []string{"-rule", "B9/S"}, exitUsage},
+		{"B0 unbounded", []string{"-rule", "B03/S23", "-edge", "INFINITE"}, exitUsage},
+		{"missing pattern", []string{"-pattern", "does-not-exist.rle"}, exitError},
+		{"help", []string{"-h"}, exitOK},
+		{"bad engine", []string{"-engine", "quantum"}, exitUsage},
+		{"HashLife needs INFINITE", []string{"-engine", "HASHLIFE"}, exitUsage},
+		{"PNG too large", []string{"-width", "3000", "-height", "3000", "-gens", "1", "-cell-size", "8", "-out", "-", "-format", "png"}, exitError},
+		{"deprecated scale", []string{"-gens", "1", "-scale", "2", "-out", "-", "-format", "png"}, exitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _ := golsim("", tt.args...); code != tt.want {
+				t.Errorf("Expected exit code %d, but got %d", tt.want, code)
+			}
+		})
+	}
+}
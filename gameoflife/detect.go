@@ -0,0 +1,65 @@
+package gameoflife
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"fmt"
+	"strings"
+)
+
+// maxDetectCells bounds the work cycle detection and the history add to each
+// step: boards with more cells than this, or unbounded boards with a larger
+// population, are neither watched nor recorded.
+const maxDetectCells = 1 << 22
+
+// observe hands the generation just stepped to the history and the cycle
+// detector and, when the board first settles, classifies its objects.
+func (g *game) observe() {
+	cells, ok := g.liveCells()
+	if !ok {
+		return
+	}
+	g.history.Record(g.run.Generation(), cells)
+
+	wasSettled := g.detector.Verdict().Settled()
+	v := g.detector.Observe(int(g.run.Generation()), cells)
+	switch {
+	case !v.Settled():
+		g.objects = ""
+	case !wasSettled && g.ltl == nil:
+		// Objects are classified by running them under a Life-like rule.
+		g.objects = summarizeObjects(g.rule, cells)
+	}
+}
+
+// forgetCycle drops the detector's history after the board was edited, and
+// marks the edited generation to be recorded again before the next step.
+func (g *game) forgetCycle() {
+	g.detector.Reset()
+	g.objects = ""
+	g.historyDirty = true
+}
+
+// summarizeObjects counts the objects of a settled board by behaviour, for
+// example "3 still life, 1 oscillator (p2)".
+func summarizeObjects(rule ddd.Rule, cells []engine.Cell) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, object := range engine.SplitObjects(cells) {
+		v := engine.ClassifyObject(rule, object, engine.DefaultHistory)
+		label := v.Behaviour.String()
+		if v.Behaviour == engine.Oscillator || v.Behaviour == engine.Spaceship {
+			label = fmt.Sprintf("%s (p%d)", label, v.Period)
+		}
+		if counts[label] == 0 {
+			order = append(order, label)
+		}
+		counts[label]++
+	}
+
+	parts := make([]string, len(order))
+	for i, label := range order {
+		parts[i] = fmt.Sprintf("%d %s", counts[label], label)
+	}
+	return strings.Join(parts, ", ")
+}
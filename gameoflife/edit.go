@@ -0,0 +1,199 @@
+package gameoflife
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// cells returns the current generation, whichever kind of board holds it.
+func (g *game) cells() core.Grid[bool] {
+	if g.unbounded() {
+		return g.sparseRead
+	}
+	return g.read
+}
+
+// editor is a view of the current generation whose writes edit both
+// buffers, keep their value through the next step and respect the board
+// edges. Writes off a fixed board fail with core.ErrOutOfBounds.
+type editor struct {
+	g *game
+}
+
+var _ core.Grid[bool] = editor{}
+
+func (e editor) Coordinate(x int, y int) bool {
+	return e.g.cells().Coordinate(x, y)
+}
+
+func (e editor) SetCoordinate(x int, y int, value bool) error {
+	g := e.g
+	if !g.unbounded() && !g.read.InBounds(x, y) {
+		return core.ErrOutOfBounds
+	}
+	g.addSkippable(skippableItems{y, x})
+	g.setCell(x, y, value)
+	g.forgetCycle()
+	return nil
+}
+
+// edits is the editor with its writes recorded for undo.
+func (g *game) edits() core.Grid[bool] {
+	return core.Recorded[bool](editor{g}, g.undo)
+}
+
+// ctrlHeld reports whether Control (or Command on macOS) is down.
+func ctrlHeld() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
+
+// handleSelection runs the clipboard, undo and transform keys: Ctrl+C/X/V
+// copy, cut and paste, Ctrl+Z and Ctrl+Y (or Ctrl+Shift+Z) undo and redo, O
+// rotates the selection clockwise, X and Y mirror it and Delete empties it.
+func (g *game) handleSelection() {
+	if ctrlHeld() {
+		shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+		switch {
+		case inpututil.IsKeyJustPressed(ebiten.KeyZ) && !shift:
+			g.undoEdit()
+		case inpututil.IsKeyJustPressed(ebiten.KeyY), inpututil.IsKeyJustPressed(ebiten.KeyZ):
+			g.redoEdit()
+		case inpututil.IsKeyJustPressed(ebiten.KeyC):
+			g.copySelection()
+		case inpututil.IsKeyJustPressed(ebiten.KeyX):
+			if g.copySelection() {
+				g.fillSelection(false)
+			}
+		case inpututil.IsKeyJustPressed(ebiten.KeyV):
+			g.paste()
+		}
+		return
+	}
+
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyO) && !g.pickerOpen():
+		// With the Placer selected, O rotates the pattern instead.
+		g.transformSelection((*core.Region[bool]).Rotate)
+	case inpututil.IsKeyJustPressed(ebiten.KeyX):
+		g.transformSelection((*core.Region[bool]).FlipHorizontal)
+	case inpututil.IsKeyJustPressed(ebiten.KeyY):
+		g.transformSelection((*core.Region[bool]).FlipVertical)
+	case inpututil.IsKeyJustPressed(ebiten.KeyDelete), inpututil.IsKeyJustPressed(ebiten.KeyBackspace):
+		g.fillSelection(false)
+	}
+}
+
+// copySelection puts the selected cells on the clipboard as RLE.
+func (g *game) copySelection() bool {
+	x, y, width, height, ok := g.tools.Selection()
+	if !ok {
+		g.message = "Nothing selected"
+		return false
+	}
+
+	p := ddd.PatternFromRegion(core.Extract(g.cells(), x, y, width, height))
+	p.Rule = g.ruleName()
+	var buf bytes.Buffer
+	if err := ddd.WriteRLE(&buf, p); err != nil {
+		g.message = err.Error()
+		return false
+	}
+	if err := g.clipboard.WriteText(buf.String()); err != nil {
+		// The text is still on the in-process clipboard.
+		g.message = fmt.Sprintf("Copied %dx%d (system clipboard unavailable)", width, height)
+		return true
+	}
+	g.message = fmt.Sprintf("Copied %dx%d", width, height)
+	return true
+}
+
+// paste stamps the pattern on the clipboard, in any supported format, with
+// its top-left corner under the cursor, and selects it.
+func (g *game) paste() {
+	text, err := g.clipboard.ReadText()
+	if err == nil && strings.TrimSpace(text) == "" {
+		err = fmt.Errorf("clipboard is empty")
+	}
+	var p *ddd.Pattern
+	if err == nil {
+		p, _, err = ddd.ReadPattern("clipboard", strings.NewReader(text))
+	}
+	if err != nil {
+		g.message = fmt.Sprintf("Paste: %v", err)
+		return
+	}
+
+	x, y := g.cam.Cell(ebiten.CursorPosition())
+	g.undo.Begin()
+	core.Stamp(g.edits(), p.Region(), x, y, nil)
+	g.undo.End()
+	g.tools.SetSelection(x, y, p.Width, p.Height)
+	g.message = fmt.Sprintf("Pasted %dx%d", p.Width, p.Height)
+}
+
+// transformSelection replaces the selected cells with transform applied to
+// them, keeping the top-left corner in place.
+func (g *game) transformSelection(transform func(*core.Region[bool]) *core.Region[bool]) {
+	x, y, width, height, ok := g.tools.Selection()
+	if !ok {
+		return
+	}
+	r := transform(core.Extract(g.cells(), x, y, width, height))
+	g.undo.Begin()
+	defer g.undo.End()
+	g.fillSelection(false)
+	core.Stamp(g.edits(), r, x, y, nil)
+	g.tools.SetSelection(x, y, r.Width, r.Height)
+}
+
+func (g *game) fillSelection(alive bool) {
+	x, y, width, height, ok := g.tools.Selection()
+	if !ok {
+		return
+	}
+	r := core.NewRegion[bool](width, height)
+	for i := range r.Cells {
+		r.Cells[i] = alive
+	}
+	g.undo.Begin()
+	core.Stamp(g.edits(), r, x, y, nil)
+	g.undo.End()
+}
+
+// undoEdit reverts the last edit. One made on an earlier generation is
+// undone there: the board is rewound to that generation first, as the
+// timeline would, so the edit goes along with everything it led to.
+func (g *game) undoEdit() {
+	gen, rewind := g.undo.Rewind()
+	if rewind {
+		if !g.restore(gen) {
+			g.undo.Clear()
+			g.message = fmt.Sprintf("Cannot undo: generation %d is no longer in the history", gen)
+			return
+		}
+		g.undo.SetTag(gen)
+	}
+	if !g.undo.Undo(editor{g}) {
+		g.message = "Nothing to undo"
+		return
+	}
+	g.message = "Undone"
+	if rewind {
+		g.message = fmt.Sprintf("Undone at generation %d", gen)
+	}
+}
+
+// redoEdit puts back the last edit undone.
+func (g *game) redoEdit() {
+	if !g.undo.Redo(editor{g}) {
+		g.message = "Nothing to redo"
+		return
+	}
+	g.message = "Redone"
+}
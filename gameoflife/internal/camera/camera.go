@@ -0,0 +1,114 @@
+// Package camera maps between screen pixels and board cells for a view that
+// can pan and zoom. It has no Ebiten dependency so it can be tested on its own.
+package camera
+
+import "math"
+
+// The zoom is in screen pixels per cell.
+const (
+	MinZoom = 1.0 / 64
+	MaxZoom = 64
+)
+
+// Camera is a view onto a board. World coordinates are in cells; cell (x, y)
+// covers [x, x+1) x [y, y+1).
+type Camera interface {
+	Zoom() float64
+	// Position returns the world coordinate at the top-left of the screen.
+	Position() (x, y float64)
+	ScreenToWorld(px, py float64) (x, y float64)
+	WorldToScreen(x, y float64) (px, py float64)
+	// Cell returns the cell under the screen pixel (px, py).
+	Cell(px, py int) (x, y int)
+	// Pan moves the view by (dx, dy) screen pixels; content follows the
+	// movement, as when dragging it.
+	Pan(dx, dy float64)
+	// ZoomAt multiplies the zoom by factor, keeping the world point under
+	// (px, py) where it is on screen.
+	ZoomAt(px, py, factor float64)
+	// CenterOn puts the world point (x, y) in the middle of the screen.
+	CenterOn(x, y float64, screenWidth, screenHeight int)
+	// Fit zooms and centres so the world rectangle at (x, y) of the given size
+	// fills as much of the screen as it can.
+	Fit(x, y, width, height float64, screenWidth, screenHeight int)
+	// Resize keeps the centre of the view in place when the screen changes size.
+	Resize(oldWidth, oldHeight, newWidth, newHeight int)
+	// Visible returns the cells at least partly on screen, as the half-open
+	// range [minX, maxX) x [minY, maxY).
+	Visible(screenWidth, screenHeight int) (minX, minY, maxX, maxY int)
+}
+
+type camera struct {
+	x, y float64
+	zoom float64
+}
+
+var _ Camera = (*camera)(nil)
+
+// New returns a camera at the world origin with the given zoom.
+func New(zoom float64) Camera {
+	return newCamera(zoom)
+}
+
+func newCamera(zoom float64) *camera {
+	return &camera{zoom: clampZoom(zoom)}
+}
+
+func clampZoom(zoom float64) float64 {
+	return math.Min(MaxZoom, math.Max(MinZoom, zoom))
+}
+
+func (c *camera) Zoom() float64 {
+	return c.zoom
+}
+
+func (c *camera) Position() (x, y float64) {
+	return c.x, c.y
+}
+
+func (c *camera) ScreenToWorld(px, py float64) (x, y float64) {
+	return c.x + px/c.zoom, c.y + py/c.zoom
+}
+
+func (c *camera) WorldToScreen(x, y float64) (px, py float64) {
+	return (x - c.x) * c.zoom, (y - c.y) * c.zoom
+}
+
+func (c *camera) Cell(px, py int) (x, y int) {
+	wx, wy := c.ScreenToWorld(float64(px), float64(py))
+	return int(math.Floor(wx)), int(math.Floor(wy))
+}
+
+func (c *camera) Pan(dx, dy float64) {
+	c.x -= dx / c.zoom
+	c.y -= dy / c.zoom
+}
+
+func (c *camera) ZoomAt(px, py, factor float64) {
+	wx, wy := c.ScreenToWorld(px, py)
+	c.zoom = clampZoom(c.zoom * factor)
+	c.x, c.y = wx-px/c.zoom, wy-py/c.zoom
+}
+
+func (c *camera) CenterOn(x, y float64, screenWidth, screenHeight int) {
+	c.x = x - float64(screenWidth)/2/c.zoom
+	c.y = y - float64(screenHeight)/2/c.zoom
+}
+
+func (c *camera) Fit(x, y, width, height float64, screenWidth, screenHeight int) {
+	if width > 0 && height > 0 {
+		c.zoom = clampZoom(math.Min(float64(screenWidth)/width, float64(screenHeight)/height))
+	}
+	c.CenterOn(x+width/2, y+height/2, screenWidth, screenHeight)
+}
+
+func (c *camera) Resize(oldWidth, oldHeight, newWidth, newHeight int) {
+	cx, cy := c.ScreenToWorld(float64(oldWidth)/2, float64(oldHeight)/2)
+	c.CenterOn(cx, cy, newWidth, newHeight)
+}
+
+func (c *camera) Visible(screenWidth, screenHeight int) (minX, minY, maxX, maxY int) {
+	x0, y0 := c.ScreenToWorld(0, 0)
+	x1, y1 := c.ScreenToWorld(float64(screenWidth), float64(screenHeight))
+	return int(math.Floor(x0)), int(math.Floor(y0)), int(math.Ceil(x1)), int(math.Ceil(y1))
+}
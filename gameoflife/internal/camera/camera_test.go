@@ -0,0 +1,81 @@
+package camera
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestCamera_CellAtAnyZoom(t *testing.T) {
+	c := newCamera(10)
+	if x, y := c.Cell(25, 9); x != 2 || y != 0 {
+		t.Errorf("Expected cell (2, 0), but got (%d, %d)", x, y)
+	}
+
+	c.Pan(5, 5)
+	if x, y := c.Cell(0, 0); x != -1 || y != -1 {
+		t.Errorf("Expected cell (-1, -1) after panning, but got (%d, %d)", x, y)
+	}
+
+	c = newCamera(0.25)
+	if x, y := c.Cell(3, 4); x != 12 || y != 16 {
+		t.Errorf("Expected cell (12, 16) when zoomed out, but got (%d, %d)", x, y)
+	}
+}
+
+func TestCamera_ZoomAtKeepsTheCursorPointFixed(t *testing.T) {
+	c := newCamera(10)
+	c.Pan(-33, 17)
+	wx, wy := c.ScreenToWorld(120, 80)
+
+	c.ZoomAt(120, 80, 1.7)
+	gx, gy := c.ScreenToWorld(120, 80)
+	if !near(wx, gx) || !near(wy, gy) {
+		t.Errorf("Expected (%v, %v) to stay under the cursor, but got (%v, %v)", wx, wy, gx, gy)
+	}
+	if !near(c.Zoom(), 17) {
+		t.Errorf("Expected zoom 17, but got %v", c.Zoom())
+	}
+}
+
+func TestCamera_ZoomIsClamped(t *testing.T) {
+	c := newCamera(1)
+	c.ZoomAt(0, 0, 1e9)
+	if c.Zoom() != MaxZoom {
+		t.Errorf("Expected zoom %v, but got %v", MaxZoom, c.Zoom())
+	}
+	c.ZoomAt(0, 0, 1e-9)
+	if c.Zoom() != MinZoom {
+		t.Errorf("Expected zoom %v, but got %v", MinZoom, c.Zoom())
+	}
+}
+
+func TestCamera_FitShowsTheWholeRectangle(t *testing.T) {
+	c := newCamera(10)
+	c.Fit(0, 0, 1000, 500, 800, 600)
+
+	if !near(c.Zoom(), 0.8) {
+		t.Errorf("Expected zoom 0.8, but got %v", c.Zoom())
+	}
+	minX, minY, maxX, maxY := c.Visible(800, 600)
+	if minX > 0 || minY > 0 || maxX < 1000 || maxY < 500 {
+		t.Errorf("Expected the board to be visible, but got [%d, %d) x [%d, %d)", minX, maxX, minY, maxY)
+	}
+	if px, py := c.WorldToScreen(500, 250); !near(px, 400) || !near(py, 300) {
+		t.Errorf("Expected the board centre at (400, 300), but got (%v, %v)", px, py)
+	}
+}
+
+func TestCamera_ResizeKeepsTheCentre(t *testing.T) {
+	c := newCamera(4)
+	c.Pan(-100, -40)
+	cx, cy := c.ScreenToWorld(400, 300)
+
+	c.Resize(800, 600, 1200, 500)
+	if gx, gy := c.ScreenToWorld(600, 250); !near(cx, gx) || !near(cy, gy) {
+		t.Errorf("Expected the centre to stay at (%v, %v), but got (%v, %v)", cx, cy, gx, gy)
+	}
+}
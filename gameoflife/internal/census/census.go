@@ -0,0 +1,315 @@
+// Package census runs random soups until they stabilize and tallies the
+// objects they leave behind, by canonical apgcode. Every soup has its own
+// seed derived from a base seed, so a census is reproducible whatever the
+// number of workers.
+package census
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"SideProjectGames/internal/rng"
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Options describe a census.
+type Options struct {
+	// Soups is how many soups to run.
+	Soups int
+	// Width x Height is the size of each soup, placed on an unbounded board.
+	Width, Height int
+	// Density is the chance of each soup cell starting alive.
+	Density float64
+	// Symmetry is the symmetry every soup is given.
+	Symmetry ddd.Symmetry
+	Rule     ddd.Rule
+	// Seed is the base seed; soup i is seeded with Seed + i.
+	Seed int64
+	// Workers is how many soups run at once; 0 means one per CPU.
+	Workers int
+	// MaxGenerations is how long a soup may run before it is given up on.
+	MaxGenerations int
+	// MaxPeriod is the longest oscillator or spaceship period recognized.
+	MaxPeriod int
+}
+
+// DefaultOptions is a census of a thousand 16x16 Life soups at half density.
+var DefaultOptions = Options{
+	Soups:          1000,
+	Width:          16,
+	Height:         16,
+	Density:        0.5,
+	Rule:           ddd.Conway,
+	Seed:           1,
+	MaxGenerations: 20000,
+	MaxPeriod:      30,
+}
+
+// SoupResult is the outcome of one soup.
+type SoupResult struct {
+	Seed int64
+	// Generations is how long the soup ran before it stabilized, or
+	// MaxGenerations if it never did.
+	Generations int
+	Stabilized  bool
+	// Objects counts the objects left by a stabilized soup by code.
+	Objects map[string]int
+}
+
+// Soup returns the live cells of the soup drawn from seed, in reading order.
+func Soup(seed int64, opts Options) []engine.Cell {
+	cells := engine.SparseLiveCells(soupBoard(seed, opts))
+	slices.SortFunc(cells, func(a, b engine.Cell) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	return cells
+}
+
+// soupBoard seeds a Width x Height soup at the origin of an unbounded board.
+func soupBoard(seed int64, opts Options) ddd.SparseBoard {
+	b := ddd.NewSparseBoard()
+	b.SeedRegion(rng.New(seed), ddd.SeedOptions{
+		Density:  opts.Density,
+		Width:    opts.Width,
+		Height:   opts.Height,
+		Symmetry: opts.Symmetry,
+	})
+	return b
+}
+
+// RunSoup runs the soup drawn from seed until it stabilizes and takes its
+// census. A soup is stable once its population has repeated with some period
+// up to MaxPeriod for MaxPeriod generations, every object settles on its own
+// and every spaceship is heading away from the rest.
+func RunSoup(opts Options, seed int64) SoupResult {
+	result := SoupResult{Seed: seed, Generations: opts.MaxGenerations}
+	current, next := soupBoard(seed, opts), ddd.NewSparseBoard()
+	stepper := engine.NewSparseStepper(opts.Rule)
+
+	window := 2 * opts.MaxPeriod
+	populations := make([]int, 0, opts.MaxGenerations+1)
+	populations = append(populations, current.Population())
+	nextCheck := window
+	for gen := 1; gen <= opts.MaxGenerations; gen++ {
+		stepper.Step(current, next)
+		current, next = next, current
+		populations = append(populations, current.Population())
+		if gen < nextCheck || !periodic(populations[len(populations)-window:], opts.MaxPeriod) {
+			continue
+		}
+		if objects, ok := settle(opts, engine.SparseLiveCells(current)); ok {
+			result.Generations, result.Stabilized, result.Objects = gen, true, objects
+			return result
+		}
+		// Something is still interacting; give it a period before looking again.
+		nextCheck = gen + opts.MaxPeriod
+	}
+	return result
+}
+
+// periodic reports whether the second half of populations repeats the first
+// with a period of at most maxPeriod.
+func periodic(populations []int, maxPeriod int) bool {
+	for p := 1; p <= maxPeriod; p++ {
+		repeats := true
+		for i := len(populations) - maxPeriod; i < len(populations); i++ {
+			if populations[i] != populations[i-p] {
+				repeats = false
+				break
+			}
+		}
+		if repeats {
+			return true
+		}
+	}
+	return false
+}
+
+// settle classifies the objects of cells, reporting false if any of them has
+// not settled or a spaceship may yet run into the ash.
+func settle(opts Options, cells []engine.Cell) (map[string]int, bool) {
+	type object struct {
+		cells   []engine.Cell
+		verdict engine.Verdict
+	}
+	var objects []object
+	var ash box
+	for _, cells := range engine.SplitObjects(cells) {
+		v := engine.ClassifyObject(opts.Rule, cells, opts.MaxPeriod)
+		if !v.Settled() || v.Behaviour == engine.Extinct {
+			return nil, false
+		}
+		objects = append(objects, object{cells, v})
+		if v.Behaviour != engine.Spaceship {
+			ash = ash.union(boxOf(cells))
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, o := range objects {
+		if o.verdict.Behaviour == engine.Spaceship && !boxOf(o.cells).escaping(ash, o.verdict.DX, o.verdict.DY) {
+			return nil, false
+		}
+		counts[code(opts.Rule, o.cells, o.verdict)]++
+	}
+	return counts, true
+}
+
+// box is an inclusive bounding box; the zero box is empty.
+type box struct {
+	minX, minY, maxX, maxY int
+	ok                     bool
+}
+
+func boxOf(cells []engine.Cell) box {
+	var b box
+	for _, c := range cells {
+		b = b.union(box{c.X, c.Y, c.X, c.Y, true})
+	}
+	return b
+}
+
+func (b box) union(o box) box {
+	switch {
+	case !b.ok:
+		return o
+	case !o.ok:
+		return b
+	}
+	return box{min(b.minX, o.minX), min(b.minY, o.minY), max(b.maxX, o.maxX), max(b.maxY, o.maxY), true}
+}
+
+// escaping reports whether a spaceship in b moving by (dx, dy) each period
+// is clear of ash and getting further away from it on some axis. The margin
+// allows for oscillators reaching past the phase the ash box was taken in.
+func (b box) escaping(ash box, dx, dy int) bool {
+	const margin = 2
+	return !ash.ok ||
+		dx > 0 && b.minX > ash.maxX+margin ||
+		dx < 0 && b.maxX < ash.minX-margin ||
+		dy > 0 && b.minY > ash.maxY+margin ||
+		dy < 0 && b.maxY < ash.minY-margin
+}
+
+// Result is the outcome of a census.
+type Result struct {
+	Options Options
+	// Unstabilized is how many soups hit MaxGenerations.
+	Unstabilized int
+	// Counts is how many of each object the stabilized soups left, by code.
+	Counts map[string]int
+}
+
+// Run takes a census of opts.Soups soups across opts.Workers goroutines.
+// progress, if not nil, is called after each soup with the number finished.
+func Run(opts Options, progress func(done int)) Result {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]SoupResult, opts.Soups)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for range min(workers, max(1, opts.Soups)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = RunSoup(opts, opts.Seed+int64(i))
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range opts.Soups {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	census := Result{Options: opts, Counts: make(map[string]int)}
+	for _, r := range results {
+		if !r.Stabilized {
+			census.Unstabilized++
+		}
+		for code, n := range r.Objects {
+			census.Counts[code] += n
+		}
+	}
+	return census
+}
+
+// Row is one line of the frequency table.
+type Row struct {
+	Code  string  `json:"code"`
+	Count int     `json:"count"`
+	Share float64 `json:"share"`
+}
+
+// Table lists the objects seen, most common first and then by code. Share
+// is each code's fraction of all objects.
+func (r Result) Table() []Row {
+	total := 0
+	for _, n := range r.Counts {
+		total += n
+	}
+	rows := make([]Row, 0, len(r.Counts))
+	for code, n := range r.Counts {
+		rows = append(rows, Row{Code: code, Count: n, Share: float64(n) / float64(total)})
+	}
+	slices.SortFunc(rows, func(a, b Row) int {
+		return cmp.Or(b.Count-a.Count, cmp.Compare(a.Code, b.Code))
+	})
+	return rows
+}
+
+// WriteCSV writes the frequency table with a code,count,share header.
+func (r Result) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"code", "count", "share"})
+	for _, row := range r.Table() {
+		cw.Write([]string{row.Code, strconv.Itoa(row.Count), strconv.FormatFloat(row.Share, 'g', 6, 64)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the census settings and frequency table as a JSON object.
+func (r Result) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Rule         string  `json:"rule"`
+		Soups        int     `json:"soups"`
+		Width        int     `json:"width"`
+		Height       int     `json:"height"`
+		Density      float64 `json:"density"`
+		Symmetry     string  `json:"symmetry"`
+		Seed         int64   `json:"seed"`
+		Unstabilized int     `json:"unstabilized"`
+		Objects      []Row   `json:"objects"`
+	}{
+		Rule:         r.Options.Rule.String(),
+		Soups:        r.Options.Soups,
+		Width:        r.Options.Width,
+		Height:       r.Options.Height,
+		Density:      r.Options.Density,
+		Symmetry:     r.Options.Symmetry.String(),
+		Seed:         r.Options.Seed,
+		Unstabilized: r.Unstabilized,
+		Objects:      r.Table(),
+	})
+}
@@ -0,0 +1,127 @@
+package census
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"bytes"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func smallCensus() Options {
+	opts := DefaultOptions
+	opts.Soups = 12
+	opts.Seed = 42
+	return opts
+}
+
+func soupOptions(width, height int, density float64) Options {
+	opts := DefaultOptions
+	opts.Width, opts.Height, opts.Density = width, height, density
+	return opts
+}
+
+func TestSoup_IsReproducible(t *testing.T) {
+	a, b := Soup(7, DefaultOptions), Soup(7, DefaultOptions)
+	if !slices.Equal(a, b) {
+		t.Error("Expected the same seed to give the same soup")
+	}
+	if slices.Equal(a, Soup(8, DefaultOptions)) {
+		t.Error("Expected another seed to give another soup")
+	}
+	if n := len(Soup(1, soupOptions(100, 100, 0.25))); n < 2300 || n > 2700 {
+		t.Errorf("Expected about 2500 cells at density 0.25, but got %d", n)
+	}
+	if n := len(Soup(1, soupOptions(10, 10, 0))); n != 0 {
+		t.Errorf("Expected no cells at density 0, but got %d", n)
+	}
+}
+
+func TestSoup_Symmetry(t *testing.T) {
+	opts := DefaultOptions
+	opts.Symmetry = ddd.C2
+	cells := Soup(5, opts)
+	live := make(map[engine.Cell]bool, len(cells))
+	for _, c := range cells {
+		live[c] = true
+	}
+	for _, c := range cells {
+		if mirror := (engine.Cell{X: opts.Width - 1 - c.X, Y: opts.Height - 1 - c.Y}); !live[mirror] {
+			t.Fatalf("Expected %v to be alive as the half turn of %v", mirror, c)
+		}
+	}
+}
+
+func TestRunSoup_Stabilizes(t *testing.T) {
+	r := RunSoup(DefaultOptions, 3)
+	if !r.Stabilized {
+		t.Fatalf("Expected soup 3 to stabilize within %d generations", DefaultOptions.MaxGenerations)
+	}
+	for code := range r.Objects {
+		if code == Pathological {
+			t.Errorf("Expected every object of a stable soup to be classified, but got %v", r.Objects)
+		}
+	}
+}
+
+func TestRunSoup_GivesUp(t *testing.T) {
+	opts := DefaultOptions
+	opts.MaxGenerations = 10
+	if r := RunSoup(opts, 3); r.Stabilized || r.Generations != 10 {
+		t.Errorf("Expected the soup to be given up after 10 generations, but got %+v", r)
+	}
+}
+
+func TestRun_IsReproducibleAcrossWorkerCounts(t *testing.T) {
+	opts := smallCensus()
+	opts.Workers = 1
+	serial := Run(opts, nil)
+	opts.Workers = 8
+	calls := 0
+	parallel := Run(opts, func(done int) { calls++ })
+
+	if !maps.Equal(serial.Counts, parallel.Counts) || serial.Unstabilized != parallel.Unstabilized {
+		t.Errorf("Expected the same census with 1 and 8 workers, but got %v and %v", serial.Counts, parallel.Counts)
+	}
+	if calls != opts.Soups {
+		t.Errorf("Expected progress after each of %d soups, but got %d calls", opts.Soups, calls)
+	}
+	if serial.Counts["xs4_33"] == 0 {
+		t.Errorf("Expected blocks among the ash, but got %v", serial.Counts)
+	}
+}
+
+func TestResult_Write(t *testing.T) {
+	r := Result{
+		Options: smallCensus(),
+		Counts:  map[string]int{"xs4_33": 6, "xp2_7": 3, "xs6_696": 3},
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want := "code,count,share\nxs4_33,6,0.5\nxp2_7,3,0.25\nxs6_696,3,0.25\n"
+	if buf.String() != want {
+		t.Errorf("Expected CSV\n%s\nbut got\n%s", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	var decoded struct {
+		Rule    string
+		Soups   int
+		Objects []Row
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, but got %v", err)
+	}
+	if decoded.Soups != 12 || !strings.Contains(decoded.Rule, "B3/S23") || len(decoded.Objects) != 3 || decoded.Objects[0].Code != "xs4_33" {
+		t.Errorf("Expected the census settings and table, but got %+v", decoded)
+	}
+}
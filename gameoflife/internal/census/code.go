@@ -0,0 +1,144 @@
+package census
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"fmt"
+	"strings"
+)
+
+// Pathological is the code of an object that does not settle into a cycle
+// on its own within the period limit.
+const Pathological = "PATHOLOGICAL"
+
+// digits are the extended Wechsler column digits, one per 5-bit column.
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// transforms are the eight rotations and reflections of the square.
+var transforms = [8]func(c engine.Cell) engine.Cell{
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: c.X, Y: c.Y} },
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: -c.Y, Y: c.X} },
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: -c.X, Y: -c.Y} },
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: c.Y, Y: -c.X} },
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: -c.X, Y: c.Y} },
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: c.X, Y: -c.Y} },
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: c.Y, Y: c.X} },
+	func(c engine.Cell) engine.Cell { return engine.Cell{X: -c.Y, Y: -c.X} },
+}
+
+// Code returns the canonical apgcode of an object: a prefix naming its class
+// ("xs<population>_" for still lifes, "xp<period>_" for oscillators,
+// "xq<period>_" for spaceships) and its extended Wechsler encoding. Of every
+// phase, rotation and reflection the shortest encoding wins, then the
+// alphabetically first, so any copy of an object gets the same code. Objects
+// that do not settle within maxPeriod generations on their own are
+// Pathological.
+func Code(rule ddd.Rule, object []engine.Cell, maxPeriod int) string {
+	return code(rule, object, engine.ClassifyObject(rule, object, maxPeriod))
+}
+
+// code is Code for an object already classified as v.
+func code(rule ddd.Rule, object []engine.Cell, v engine.Verdict) string {
+	var prefix string
+	switch v.Behaviour {
+	case engine.StillLife:
+		prefix = fmt.Sprintf("xs%d_", len(object))
+	case engine.Oscillator:
+		prefix = fmt.Sprintf("xp%d_", v.Period)
+	case engine.Spaceship:
+		prefix = fmt.Sprintf("xq%d_", v.Period)
+	default:
+		return Pathological
+	}
+
+	best := ""
+	for _, phase := range phases(rule, object, v.Period) {
+		for _, transform := range transforms {
+			moved := make([]engine.Cell, len(phase))
+			for i, c := range phase {
+				moved[i] = transform(c)
+			}
+			if w := Wechsler(moved); best == "" || len(w) < len(best) || len(w) == len(best) && w < best {
+				best = w
+			}
+		}
+	}
+	return prefix + best
+}
+
+// phases returns the live cells of each of the period generations of object.
+func phases(rule ddd.Rule, object []engine.Cell, period int) [][]engine.Cell {
+	current, next := ddd.NewSparseBoard(), ddd.NewSparseBoard()
+	for _, c := range object {
+		current.SetCoordinate(c.X, c.Y, true)
+	}
+	stepper := engine.NewSparseStepper(rule)
+	out := [][]engine.Cell{object}
+	for len(out) < period {
+		stepper.Step(current, next)
+		current, next = next, current
+		out = append(out, engine.SparseLiveCells(current))
+	}
+	return out
+}
+
+// Wechsler encodes cells in extended Wechsler format, relative to their
+// bounding box. The box is cut into strips five rows high, separated by "z";
+// each column of a strip is one digit whose bits, lowest first, are its five
+// cells from the top. Trailing empty columns of a strip are dropped and runs
+// of empty columns are shortened to "w" (two), "x" (three) or "y" and a digit
+// (four to thirty-nine).
+func Wechsler(cells []engine.Cell) string {
+	if len(cells) == 0 {
+		return ""
+	}
+	minX, minY, maxX, maxY := cells[0].X, cells[0].Y, cells[0].X, cells[0].Y
+	for _, c := range cells[1:] {
+		minX, maxX = min(minX, c.X), max(maxX, c.X)
+		minY, maxY = min(minY, c.Y), max(maxY, c.Y)
+	}
+	width, strips := maxX-minX+1, (maxY-minY)/5+1
+	columns := make([][]int, strips)
+	for i := range columns {
+		columns[i] = make([]int, width)
+	}
+	for _, c := range cells {
+		y := c.Y - minY
+		columns[y/5][c.X-minX] |= 1 << (y % 5)
+	}
+
+	var b strings.Builder
+	for i, strip := range columns {
+		if i > 0 {
+			b.WriteByte('z')
+		}
+		zeros := 0
+		for _, column := range strip {
+			if column == 0 {
+				zeros++
+				continue
+			}
+			writeZeros(&b, zeros)
+			zeros = 0
+			b.WriteByte(digits[column])
+		}
+	}
+	return b.String()
+}
+
+func writeZeros(b *strings.Builder, n int) {
+	for n >= 4 {
+		run := min(n, 39)
+		b.WriteByte('y')
+		b.WriteByte(digits[run-4])
+		n -= run
+	}
+	switch n {
+	case 1:
+		b.WriteByte('0')
+	case 2:
+		b.WriteByte('w')
+	case 3:
+		b.WriteByte('x')
+	}
+}
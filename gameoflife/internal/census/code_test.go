@@ -0,0 +1,79 @@
+package census
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"strings"
+	"testing"
+)
+
+// cellsOf reads an RLE body and returns its live cells.
+func cellsOf(t *testing.T, rle string) []engine.Cell {
+	t.Helper()
+	p, err := ddd.ReadRLE(strings.NewReader(rle))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	var cells []engine.Cell
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			if p.Alive(x, y) {
+				cells = append(cells, engine.Cell{X: x, Y: y})
+			}
+		}
+	}
+	return cells
+}
+
+func TestCode_KnownObjects(t *testing.T) {
+	tests := []struct {
+		name string
+		rle  string
+		want string
+	}{
+		{"block", "x = 2, y = 2\n2o$2o!", "xs4_33"},
+		{"beehive", "x = 4, y = 3\nb2ob$o2bo$b2o!", "xs6_696"},
+		{"loaf", "x = 4, y = 4\nb2ob$o2bo$bobo$2bo!", "xs7_2596"},
+		{"boat", "x = 3, y = 3\n2ob$obo$bo!", "xs5_253"},
+		{"tub", "x = 3, y = 3\nbo$obo$bo!", "xs4_252"},
+		{"pond", "x = 4, y = 4\nb2ob$o2bo$o2bo$b2o!", "xs8_6996"},
+		{"blinker", "x = 3, y = 1\n3o!", "xp2_7"},
+		{"toad", "x = 4, y = 2\nb3o$3o!", "xp2_7e"},
+		{"beacon", "x = 4, y = 4\n2o2b$2o2b$2b2o$2b2o!", "xp2_318c"},
+		{"pulsar", "x = 13, y = 13\n2b3o3b3o2b2$o4bobo4bo$o4bobo4bo$o4bobo4bo$2b3o3b3o2b2$2b3o3b3o2b$o4bobo4bo$o4bobo4bo$o4bobo4bo2$2b3o3b3o!",
+			"xp3_co9nas0san9oczgoldlo0oldlogz1047210127401"},
+		{"glider", "x = 3, y = 3\nbo$2bo$3o!", "xq4_153"},
+		{"lightweight spaceship", "x = 5, y = 4\nbo2bo$o4b$o3bo$4o!", "xq4_6frc"},
+		{"diehard", "x = 8, y = 3\n6bob$2o6b$bo3b3o!", Pathological},
+	}
+	for _, tt := range tests {
+		if got := Code(ddd.Conway, cellsOf(t, tt.rle), 64); got != tt.want {
+			t.Errorf("Expected %s to be %s, but got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCode_IgnoresOrientationAndPosition(t *testing.T) {
+	glider := cellsOf(t, "x = 3, y = 3\nbo$2bo$3o!")
+	want := Code(ddd.Conway, glider, 64)
+	for i, transform := range transforms {
+		moved := make([]engine.Cell, len(glider))
+		for j, c := range glider {
+			moved[j] = transform(c)
+			moved[j].X += 100
+			moved[j].Y -= 7
+		}
+		if got := Code(ddd.Conway, moved, 64); got != want {
+			t.Errorf("Expected transform %d of the glider to be %s, but got %s", i, want, got)
+		}
+	}
+}
+
+func TestWechsler_ZeroRuns(t *testing.T) {
+	cells := []engine.Cell{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 7, Y: 0}, {X: 50, Y: 6}}
+	// Runs of two and three empty columns in the first strip, then fifty in
+	// the second: thirty-nine ("yz") and eleven ("y7").
+	if got := Wechsler(cells); got != "1w1x1zyzy72" {
+		t.Errorf("Expected 1w1x1zyzy72, but got %s", got)
+	}
+}
@@ -2,14 +2,22 @@ package ddd
 
 import (
 	"SideProjectGames/internal/ddd"
-	"math/rand"
-	"time"
+	"SideProjectGames/internal/rng"
+	"fmt"
+	"strings"
 )
 
 // GolBoard composes the generic ddd.Board with extra Game of Life helpers.
 type GolBoard interface {
 	ddd.Board[bool]
-	SeedBoard()
+	// FlatSlice is the cells in reading order. On a BOOL board it is the live
+	// store; a BITS board unpacks a fresh copy, so writes to it are lost and
+	// every call allocates the whole board. Change cells with SetCoordinate
+	// or CopyBoard.
+	FlatSlice() []bool
+	// SeedBoard replaces the board with a random soup drawn from r. Cells
+	// outside the seeded region die.
+	SeedBoard(r rng.Source, opts SeedOptions)
 	CountSurroundingLive(x int, y int) int
 }
 
@@ -19,15 +27,35 @@ type golBoard struct {
 
 var _ GolBoard = (*golBoard)(nil)
 
-func NewGOLBoard(width int, height int) GolBoard {
-	return newGOLBoard(width, height)
+// NewBoardOfKind creates a board with the storage named by kind: "BOOL" (one
+// bool per cell, see NewGOLBoard) or "BITS" (64 cells per word, see
+// NewBitBoard). The comparison is case-insensitive and an empty kind is BOOL.
+func NewBoardOfKind(kind string, width int, height int, topology ddd.Topology) (GolBoard, error) {
+	switch strings.ToUpper(strings.TrimSpace(kind)) {
+	case "", "BOOL":
+		return NewGOLBoard(width, height, topology), nil
+	case "BITS", "BIT", "PACKED":
+		return NewBitBoard(width, height, topology), nil
+	}
+	return nil, fmt.Errorf("unknown board %q; expected BOOL or BITS", kind)
+}
+
+// NewGOLBoard creates a board whose edges follow topology. On a Bounded board
+// everything past the edge is dead.
+func NewGOLBoard(width int, height int, topology ddd.Topology) GolBoard {
+	return newGOLBoard(width, height, topology)
 }
 
-func newGOLBoard(width int, height int) GolBoard {
-	return &golBoard{Board: ddd.NewBoard[bool](width, height)}
+func newGOLBoard(width int, height int, topology ddd.Topology) GolBoard {
+	return &golBoard{Board: ddd.NewBoard[bool](width, height, ddd.WithTopology[bool](topology))}
 }
 
 func (b *golBoard) CountSurroundingLive(x int, y int) int {
+	return countSurroundingLive(b, x, y)
+}
+
+// countSurroundingLive counts the live Moore neighbours of (x, y) on any grid.
+func countSurroundingLive(g ddd.Grid[bool], x int, y int) int {
 	surroundArray := []int{-1, 0, 1}
 	totalAlive := 0
 	for _, yOffset := range surroundArray {
@@ -36,7 +64,7 @@ func (b *golBoard) CountSurroundingLive(x int, y int) int {
 				continue
 			}
 
-			if b.Coordinate(x+xOffset, y+yOffset) {
+			if g.Coordinate(x+xOffset, y+yOffset) {
 				totalAlive += 1
 			}
 		}
@@ -45,11 +73,15 @@ func (b *golBoard) CountSurroundingLive(x int, y int) int {
 	return totalAlive
 }
 
-func (b *golBoard) SeedBoard() {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	slice := b.FlatSlice()
-	for i := 0; i < len(slice); i++ {
-		slice[i] = r.Intn(2) == 1
+func (b *golBoard) SeedBoard(r rng.Source, opts SeedOptions) {
+	b.CopyBoard(make([]bool, b.Cols()*b.Rows()))
+	Seed(b, r, onBoard(b, opts))
+}
+
+// onBoard fills in the whole board as the region when opts leaves it empty.
+func onBoard(b ddd.Board[bool], opts SeedOptions) SeedOptions {
+	if opts.Width <= 0 || opts.Height <= 0 {
+		opts.X, opts.Y, opts.Width, opts.Height = 0, 0, b.Cols(), b.Rows()
 	}
-	b.CopyBoard(slice)
+	return opts
 }
@@ -1,12 +1,16 @@
 package ddd
 
 import (
+	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"errors"
+	"math/rand"
 	"testing"
 )
 
 func TestGolBoard_CountSurroundingLive(t *testing.T) {
 	// Create a new 3x3 board for testing
-	board := newGOLBoard(3, 3)
+	board := newGOLBoard(3, 3, ddd.Toroidal)
 
 	// Set up a specific pattern of live cells
 	// X X X
@@ -32,3 +36,138 @@ func TestGolBoard_CountSurroundingLive(t *testing.T) {
 		t.Errorf("Expected %d live neighbors, but got %d", expected, liveNeighbors)
 	}
 }
+
+func TestGolBoard_BoundedEdgesAreDead(t *testing.T) {
+	board := newGOLBoard(3, 3, ddd.Bounded)
+	board.CopyBoard([]bool{
+		true, true, true,
+		true, false, true,
+		true, true, true,
+	})
+
+	// On a torus the corner sees the 7 other live cells; with hard edges it sees 2.
+	if got := board.CountSurroundingLive(0, 0); got != 2 {
+		t.Errorf("Expected 2 live neighbors at a bounded corner, but got %d", got)
+	}
+}
+
+// golBoardKinds lists every GolBoard implementation; the conformance tests
+// below run against each of them.
+var golBoardKinds = map[string]func(width, height int, topology ddd.Topology) GolBoard{
+	"BOOL": newGOLBoard,
+	"BITS": func(width, height int, topology ddd.Topology) GolBoard { return newBitBoard(width, height, topology) },
+}
+
+var allTopologies = []ddd.Topology{ddd.Toroidal, ddd.Bounded, ddd.KleinBottle, ddd.ProjectivePlane}
+
+// soup returns a repeatable row-major slice of cells.
+func soup(cols, rows int, seed int64) []bool {
+	r := rand.New(rand.NewSource(seed))
+	cells := make([]bool, cols*rows)
+	for i := range cells {
+		cells[i] = r.Intn(3) == 0
+	}
+	return cells
+}
+
+func TestGolBoardConformance_CopyBoardRoundTrips(t *testing.T) {
+	for kind, newBoard := range golBoardKinds {
+		for _, size := range [][2]int{{1, 1}, {63, 2}, {64, 3}, {65, 4}, {130, 7}} {
+			cols, rows := size[0], size[1]
+			cells := soup(cols, rows, int64(cols))
+			board := newBoard(cols, rows, ddd.Toroidal)
+			board.CopyBoard(cells)
+
+			got := board.FlatSlice()
+			for i := range cells {
+				if got[i] != cells[i] {
+					t.Fatalf("%s %dx%d: Expected cell %d to be %v, but got %v", kind, cols, rows, i, cells[i], got[i])
+				}
+				if board.Coordinate(i%cols, i/cols) != cells[i] {
+					t.Fatalf("%s %dx%d: Expected Coordinate(%d, %d) to be %v", kind, cols, rows, i%cols, i/cols, cells[i])
+				}
+			}
+		}
+	}
+}
+
+func TestGolBoardConformance_MatchesBoolBoardAcrossEdges(t *testing.T) {
+	for kind, newBoard := range golBoardKinds {
+		for _, topology := range allTopologies {
+			cols, rows := 67, 5
+			cells := soup(cols, rows, 7)
+			want := newGOLBoard(cols, rows, topology)
+			want.CopyBoard(cells)
+			got := newBoard(cols, rows, topology)
+			got.CopyBoard(cells)
+
+			for y := -rows - 2; y < 2*rows+2; y++ {
+				for x := -cols - 2; x < 2*cols+2; x++ {
+					if got.Coordinate(x, y) != want.Coordinate(x, y) {
+						t.Fatalf("%s %v: Expected Coordinate(%d, %d) to be %v", kind, topology, x, y, want.Coordinate(x, y))
+					}
+					if got.CountSurroundingLive(x, y) != want.CountSurroundingLive(x, y) {
+						t.Fatalf("%s %v: Expected %d live neighbors at (%d, %d), but got %d",
+							kind, topology, want.CountSurroundingLive(x, y), x, y, got.CountSurroundingLive(x, y))
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGolBoardConformance_SetCoordinate(t *testing.T) {
+	for kind, newBoard := range golBoardKinds {
+		board := newBoard(70, 3, ddd.Toroidal)
+		board.SetCoordinate(-1, -1, true)
+		if !board.Coordinate(69, 2) {
+			t.Errorf("%s: Expected (-1, -1) to wrap to (69, 2)", kind)
+		}
+		board.SetCoordinate(69, 2, false)
+		if board.Coordinate(-1, -1) {
+			t.Errorf("%s: Expected (69, 2) to be cleared", kind)
+		}
+
+		bounded := newBoard(70, 3, ddd.Bounded)
+		if err := bounded.SetCoordinate(70, 0, true); !errors.Is(err, ddd.ErrOutOfBounds) {
+			t.Errorf("%s: Expected ErrOutOfBounds, but got %v", kind, err)
+		}
+	}
+}
+
+func TestBitBoard_SeedBoardLeavesPaddingClear(t *testing.T) {
+	board := newBitBoard(70, 10, ddd.Toroidal)
+	board.SeedBoard(rng.New(1), DefaultSeedOptions)
+	for y := 0; y < board.Rows(); y++ {
+		if last := board.Words()[y*board.Stride()+board.Stride()-1]; last&^LastWordMask(70) != 0 {
+			t.Errorf("Expected no live cells past column 70, but row %d has %064b", y, last)
+		}
+	}
+	if got, want := board.Population(), countLive(board.FlatSlice()); got != want {
+		t.Errorf("Expected a population of %d, but got %d", want, got)
+	}
+}
+
+func countLive(cells []bool) int {
+	n := 0
+	for _, alive := range cells {
+		if alive {
+			n++
+		}
+	}
+	return n
+}
+
+func TestNewBoardOfKind(t *testing.T) {
+	if b, err := NewBoardOfKind("bits", 3, 3, ddd.Toroidal); err != nil {
+		t.Errorf("Expected BITS to be accepted, but got %v", err)
+	} else if _, ok := b.(BitBoard); !ok {
+		t.Errorf("Expected a BitBoard, but got %T", b)
+	}
+	if _, err := NewBoardOfKind("", 3, 3, ddd.Toroidal); err != nil {
+		t.Errorf("Expected the default board to be accepted, but got %v", err)
+	}
+	if _, err := NewBoardOfKind("trits", 3, 3, ddd.Toroidal); err == nil {
+		t.Error("Expected an unknown board to fail, but got nil")
+	}
+}
@@ -0,0 +1,141 @@
+package ddd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format identifies a pattern file format.
+type Format uint8
+
+const (
+	FormatRLE Format = iota
+	FormatPlaintext
+	FormatLife106
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatRLE:
+		return "RLE"
+	case FormatPlaintext:
+		return "plaintext"
+	case FormatLife106:
+		return "Life 1.06"
+	}
+	return fmt.Sprintf("Format(%d)", uint8(f))
+}
+
+// Extension returns the usual file extension for the format, including the dot.
+func (f Format) Extension() string {
+	switch f {
+	case FormatPlaintext:
+		return ".cells"
+	case FormatLife106:
+		return ".lif"
+	}
+	return ".rle"
+}
+
+// FormatFromPath picks a format from a file extension. ok is false for
+// extensions that do not identify a single format.
+func FormatFromPath(path string) (f Format, ok bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".rle":
+		return FormatRLE, true
+	case ".cells", ".txt":
+		return FormatPlaintext, true
+	case ".lif", ".life", ".06":
+		return FormatLife106, true
+	}
+	return FormatRLE, false
+}
+
+// DetectFormat sniffs the format from the start of a pattern file.
+func DetectFormat(data []byte) (Format, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, life106Header):
+			return FormatLife106, nil
+		case strings.HasPrefix(line, "#"):
+			// RLE comments; Life 1.05 and 1.06 files start with their header instead.
+			continue
+		case strings.HasPrefix(line, "!"):
+			return FormatPlaintext, nil
+		case strings.HasPrefix(strings.ToLower(line), "x") && strings.Contains(line, "="):
+			return FormatRLE, nil
+		case strings.Trim(line, ".Oo*") == "":
+			return FormatPlaintext, nil
+		default:
+			return FormatRLE, fmt.Errorf("unrecognized pattern format starting with %q", line)
+		}
+	}
+	return FormatRLE, fmt.Errorf("empty pattern file")
+}
+
+// ReadPattern reads a pattern in any supported format. The format is detected
+// from the content first, so a file with the wrong extension still reads; the
+// file name's extension is only used when the content is not recognized.
+func ReadPattern(name string, r io.Reader) (*Pattern, Format, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, FormatRLE, err
+	}
+
+	format, ok := FormatFromPath(name)
+	if detected, err := DetectFormat(data); err == nil {
+		format = detected
+	} else if !ok {
+		return nil, FormatRLE, err
+	}
+
+	var p *Pattern
+	switch format {
+	case FormatPlaintext:
+		p, err = ReadPlaintext(bytes.NewReader(data))
+	case FormatLife106:
+		p, err = ReadLife106(bytes.NewReader(data))
+	default:
+		p, err = ReadRLE(bytes.NewReader(data))
+	}
+	return p, format, err
+}
+
+// WritePattern encodes p in the given format.
+func WritePattern(w io.Writer, p *Pattern, format Format) error {
+	switch format {
+	case FormatPlaintext:
+		return WritePlaintext(w, p)
+	case FormatLife106:
+		return WriteLife106(w, p)
+	default:
+		return WriteRLE(w, p)
+	}
+}
+
+// LoadPattern reads a pattern in any supported format from r and places it on
+// b at (offsetX, offsetY).
+func LoadPattern(b GolBoard, name string, r io.Reader, offsetX int, offsetY int) (*Pattern, error) {
+	p, _, err := ReadPattern(name, r)
+	if err != nil {
+		return nil, err
+	}
+	p.Place(b, offsetX, offsetY)
+	return p, nil
+}
+
+// SavePattern writes the width x height region of b starting at (x, y) in the
+// given format.
+func SavePattern(w io.Writer, b GolBoard, format Format, rule Rule, x int, y int, width int, height int) error {
+	p := PatternFromBoard(b, x, y, width, height)
+	p.Rule = rule.String()
+	return WritePattern(w, p, format)
+}
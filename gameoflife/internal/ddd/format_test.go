@@ -0,0 +1,133 @@
+package ddd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const gliderCells = `!Name: Glider
+!The smallest spaceship.
+.O
+..O
+OOO
+`
+
+const gliderLife106 = `#Life 1.06
+0 -1
+1 0
+-1 1
+0 1
+1 1
+`
+
+func assertGlider(t *testing.T, p *Pattern) {
+	t.Helper()
+	if p.Width != 3 || p.Height != 3 {
+		t.Fatalf("Expected a 3x3 glider, but got %dx%d", p.Width, p.Height)
+	}
+	want := []bool{
+		false, true, false,
+		false, false, true,
+		true, true, true,
+	}
+	for i := range want {
+		if p.Cells[i] != want[i] {
+			t.Fatalf("Expected glider cells %v, but got %v", want, p.Cells)
+		}
+	}
+}
+
+func TestReadPlaintext(t *testing.T) {
+	p, err := ReadPlaintext(strings.NewReader(gliderCells))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	assertGlider(t, p)
+	if p.Name != "Glider" || len(p.Comments) != 1 {
+		t.Errorf("Expected name and one comment, but got %q %v", p.Name, p.Comments)
+	}
+}
+
+func TestReadLife106_NormalizesNegativeCoordinates(t *testing.T) {
+	p, err := ReadLife106(strings.NewReader(gliderLife106))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	assertGlider(t, p)
+}
+
+func TestReadLife106_RejectsFarApartCells(t *testing.T) {
+	for _, in := range []string{
+		"#Life 1.06\n0 0\n1000000 1000000\n",
+		"#Life 1.06\n-9223372036854775808 0\n9223372036854775807 0\n",
+	} {
+		if _, err := ReadLife106(strings.NewReader(in)); err == nil {
+			t.Errorf("Expected ReadLife106(%q) to refuse the size, but it succeeded", in)
+		}
+	}
+	p, err := ReadLife106(strings.NewReader("#Life 1.06\n"))
+	if err != nil || p.Width != 0 || p.Height != 0 {
+		t.Errorf("Expected an empty file to give an empty pattern, but got %v, %v", p, err)
+	}
+}
+
+func TestReadPlaintext_RejectsHugePatterns(t *testing.T) {
+	// One wide row over many short ones: a small file, a huge pattern.
+	in := strings.Repeat("O", 60000) + "\n" + strings.Repeat(".\n", 100000)
+	if _, err := ReadPlaintext(strings.NewReader(in)); err == nil {
+		t.Error("Expected a 60000x100001 plaintext pattern to be refused, but it was read")
+	}
+}
+
+func TestWriters_RoundTrip(t *testing.T) {
+	glider, err := ReadPlaintext(strings.NewReader(gliderCells))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	for _, format := range []Format{FormatRLE, FormatPlaintext, FormatLife106} {
+		var buf bytes.Buffer
+		if err := WritePattern(&buf, glider, format); err != nil {
+			t.Fatalf("%s: expected no error writing, but got %v", format, err)
+		}
+		p, detected, err := ReadPattern("clipboard", &buf)
+		if err != nil {
+			t.Fatalf("%s: expected no error reading back, but got %v", format, err)
+		}
+		if detected != format {
+			t.Errorf("Expected %s to be detected, but got %s", format, detected)
+		}
+		assertGlider(t, p)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	cases := map[string]Format{
+		gliderCells:                           FormatPlaintext,
+		".O\n..O\nOOO\n":                      FormatPlaintext,
+		gliderLife106:                         FormatLife106,
+		"#N Glider\nx = 3, y = 3\nbo$2bo$3o!": FormatRLE,
+	}
+	for in, want := range cases {
+		got, err := DetectFormat([]byte(in))
+		if err != nil || got != want {
+			t.Errorf("DetectFormat(%q) = %v, %v; expected %v", in, got, err, want)
+		}
+	}
+
+	if _, err := DetectFormat([]byte("hello world")); err == nil {
+		t.Error("Expected an error for an unrecognized format, but got nil")
+	}
+}
+
+func TestReadPattern_UsesExtensionWhenContentIsAmbiguous(t *testing.T) {
+	// An empty Life 1.06 body with a comment only is not enough to sniff.
+	_, format, err := ReadPattern("empty.lif", strings.NewReader("#D nothing here\n"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if format != FormatLife106 {
+		t.Errorf("Expected the .lif extension to pick Life 1.06, but got %s", format)
+	}
+}
@@ -0,0 +1,86 @@
+package ddd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// life106Header is the first line of every Life 1.06 file.
+const life106Header = "#Life 1.06"
+
+// ReadLife106 parses a Life 1.06 file: the "#Life 1.06" header followed by one
+// "x y" coordinate pair per live cell. Coordinates may be negative; the
+// pattern is shifted so its bounding box starts at (0, 0). Cells spread so far
+// apart that the bounding box is over MaxPatternCells are an error.
+func ReadLife106(r io.Reader) (*Pattern, error) {
+	scanner := bufio.NewScanner(r)
+	p := &Pattern{}
+	var cells [][2]int
+	minX, minY, maxX, maxY := 0, 0, -1, -1
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if name, ok := strings.CutPrefix(line, "#N "); ok {
+				p.Name = strings.TrimSpace(name)
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("life 1.06: expected \"x y\", got %q", line)
+		}
+		x, errX := strconv.Atoi(fields[0])
+		y, errY := strconv.Atoi(fields[1])
+		if errX != nil || errY != nil {
+			return nil, fmt.Errorf("life 1.06: invalid coordinate %q", line)
+		}
+
+		if len(cells) == 0 {
+			minX, minY, maxX, maxY = x, y, x, y
+		}
+		minX, maxX = min(minX, x), max(maxX, x)
+		minY, maxY = min(minY, y), max(maxY, y)
+		cells = append(cells, [2]int{x, y})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// The spans are compared unsigned so that far-apart coordinates cannot
+	// overflow into a small size.
+	if len(cells) > 0 && (uint64(maxX-minX) >= MaxPatternCells || uint64(maxY-minY) >= MaxPatternCells) {
+		return nil, fmt.Errorf("life 1.06: cells span (%d, %d) to (%d, %d), larger than the %d cell limit", minX, minY, maxX, maxY, MaxPatternCells)
+	}
+	p.Width, p.Height = maxX-minX+1, maxY-minY+1
+	if err := checkPatternSize(p.Width, p.Height); err != nil {
+		return nil, fmt.Errorf("life 1.06: %w", err)
+	}
+	p.Cells = make([]bool, p.Width*p.Height)
+	for _, c := range cells {
+		p.Cells[(c[1]-minY)*p.Width+c[0]-minX] = true
+	}
+	return p, nil
+}
+
+// WriteLife106 encodes the live cells of p as a Life 1.06 coordinate list,
+// relative to the pattern's top-left corner.
+func WriteLife106(w io.Writer, p *Pattern) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, life106Header)
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			if p.Cells[y*p.Width+x] {
+				fmt.Fprintf(bw, "%d %d\n", x, y)
+			}
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,169 @@
+package ddd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LtLRule is a Larger-than-Life rule: a cell is born or survives when the
+// weighted count of live cells in its neighbourhood, which can reach far past
+// the 8 nearest cells, falls within a range.
+type LtLRule struct {
+	states int
+	// middle is set when a live cell counts itself.
+	middle       bool
+	surviveLo    int
+	surviveHi    int
+	birthLo      int
+	birthHi      int
+	neighborhood Neighborhood
+}
+
+// namedLtLRules maps well-known Larger-than-Life rules to their notation.
+var namedLtLRules = map[string]string{
+	"BOSCO":    "R5,C0,M1,S34..58,B34..45,NM",
+	"BUGS":     "R5,C0,M1,S34..58,B34..45,NM",
+	"MAJORITY": "R4,C0,M1,S41..81,B41..81,NM",
+	"WAFFLE":   "R7,C0,M1,S100..200,B75..170,NM",
+	"GLOBE":    "R8,C0,M0,S163..223,B74..252,NM",
+}
+
+// IsLtL reports whether s is written in Larger-than-Life notation, or names
+// a well-known Larger-than-Life rule, so ParseLtL rather than ParseRule
+// should read it.
+func IsLtL(s string) bool {
+	key := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if _, ok := namedLtLRules[key]; ok {
+		return true
+	}
+	return len(key) > 1 && key[0] == 'R' && key[1] >= '0' && key[1] <= '9'
+}
+
+// ParseLtL reads a rule in Larger-than-Life notation,
+// "R5,C0,M1,S34..58,B34..45,NM" (case-insensitive, sections in any order),
+// or one of the well-known names such as "Bosco" or "Majority". R is the
+// radius, C the number of states (0 and 2 both mean an ordinary live or dead
+// cell; more add dying states as in Generations rules), M1 makes a live cell
+// count itself, S and B are the inclusive survival and birth ranges (a
+// single number is a range of one) and N the neighbourhood: M (Moore, the
+// default), N (von Neumann), H (hexagonal) or W followed by (2R+1)^2
+// hexadecimal weights in reading order.
+func ParseLtL(s string) (LtLRule, error) {
+	key := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if named, ok := namedLtLRules[key]; ok {
+		key = named
+	}
+
+	r := LtLRule{states: 2}
+	radius := -1
+	neighborhood := "M"
+	seen := make(map[byte]bool)
+	for _, part := range strings.Split(key, ",") {
+		if part == "" {
+			return LtLRule{}, fmt.Errorf("rule %q: empty section", s)
+		}
+		section := part[0]
+		if seen[section] {
+			return LtLRule{}, fmt.Errorf("rule %q: %c given twice", s, section)
+		}
+		seen[section] = true
+		value := part[1:]
+
+		var err error
+		switch section {
+		case 'R':
+			radius, err = strconv.Atoi(value)
+			if err == nil && (radius < 1 || radius > MaxRadius) {
+				err = fmt.Errorf("radius %d out of range 1-%d", radius, MaxRadius)
+			}
+		case 'C':
+			r.states, err = strconv.Atoi(value)
+			if err == nil && (r.states < 0 || r.states == 1 || r.states > 256) {
+				err = fmt.Errorf("state count %d out of range 2-256", r.states)
+			}
+			r.states = max(r.states, 2)
+		case 'M':
+			switch value {
+			case "0":
+			case "1":
+				r.middle = true
+			default:
+				err = fmt.Errorf("M%s: expected M0 or M1", value)
+			}
+		case 'S':
+			r.surviveLo, r.surviveHi, err = parseRange(value)
+		case 'B':
+			r.birthLo, r.birthHi, err = parseRange(value)
+		case 'N':
+			neighborhood = value
+		default:
+			err = fmt.Errorf("unexpected section %q", part)
+		}
+		if err != nil {
+			return LtLRule{}, fmt.Errorf("rule %q: %w", s, err)
+		}
+	}
+	for _, section := range []byte{'R', 'S', 'B'} {
+		if !seen[section] {
+			return LtLRule{}, fmt.Errorf("rule %q: expected an %c section", s, section)
+		}
+	}
+
+	var err error
+	if r.neighborhood, err = parseNeighborhood(neighborhood, radius); err != nil {
+		return LtLRule{}, fmt.Errorf("rule %q: %w", s, err)
+	}
+	return r, nil
+}
+
+// parseRange reads "34..58", or "3" for a range of one.
+func parseRange(s string) (lo, hi int, err error) {
+	loText, hiText, isRange := strings.Cut(s, "..")
+	if !isRange {
+		hiText = loText
+	}
+	if lo, err = strconv.Atoi(loText); err != nil {
+		return 0, 0, fmt.Errorf("bad range %q", s)
+	}
+	if hi, err = strconv.Atoi(hiText); err != nil {
+		return 0, 0, fmt.Errorf("bad range %q", s)
+	}
+	return lo, hi, nil
+}
+
+// States is the number of states, 2 for an ordinary live or dead cell.
+func (r LtLRule) States() int {
+	return r.states
+}
+
+// Neighborhood is the neighbourhood the rule counts.
+func (r LtLRule) Neighborhood() Neighborhood {
+	return r.neighborhood
+}
+
+// Next returns whether a cell is alive in the next generation, given count,
+// the weighted number of live cells around it with the cell itself left out.
+// A live cell adds itself when the rule says M1.
+func (r LtLRule) Next(alive bool, count int) bool {
+	if alive {
+		if r.middle {
+			count += r.neighborhood.Center()
+		}
+		return count >= r.surviveLo && count <= r.surviveHi
+	}
+	return count >= r.birthLo && count <= r.birthHi
+}
+
+// String returns the canonical notation, e.g. "R5,C0,M1,S34..58,B34..45,NM".
+func (r LtLRule) String() string {
+	states, middle := r.states, 0
+	if states == 2 {
+		states = 0
+	}
+	if r.middle {
+		middle = 1
+	}
+	return fmt.Sprintf("R%d,C%d,M%d,S%d..%d,B%d..%d,%s",
+		r.neighborhood.Radius(), states, middle, r.surviveLo, r.surviveHi, r.birthLo, r.birthHi, r.neighborhood)
+}
@@ -0,0 +1,76 @@
+package ddd
+
+import "testing"
+
+func TestParseLtL_Notations(t *testing.T) {
+	cases := map[string]string{
+		"R5,C0,M1,S34..58,B34..45,NM":   "R5,C0,M1,S34..58,B34..45,NM",
+		"r5,c0,m1,s34..58,b34..45":      "R5,C0,M1,S34..58,B34..45,NM",
+		"B34..45,S34..58,R5,M1":         "R5,C0,M1,S34..58,B34..45,NM",
+		"R1,C2,M0,S2..3,B3,NM":          "R1,C0,M0,S2..3,B3..3,NM",
+		"R2,C3,M0,S1..4,B2..3,NN":       "R2,C3,M0,S1..4,B2..3,NN",
+		"R3,C0,M0,S5..9,B6..7,NH":       "R3,C0,M0,S5..9,B6..7,NH",
+		"R1,C0,M0,S2..3,B3,NW1f1f0f1f1": "R1,C0,M0,S2..3,B3..3,NW1f1f0f1f1",
+		"Bosco":                         "R5,C0,M1,S34..58,B34..45,NM",
+		"majority":                      "R4,C0,M1,S41..81,B41..81,NM",
+	}
+	for in, want := range cases {
+		r, err := ParseLtL(in)
+		if err != nil {
+			t.Errorf("ParseLtL(%q) returned error %v", in, err)
+			continue
+		}
+		if r.String() != want {
+			t.Errorf("ParseLtL(%q) = %s; expected %s", in, r, want)
+		}
+	}
+}
+
+func TestParseLtL_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"R0,C0,M0,S1..2,B1..2",
+		"R501,C0,M0,S1..2,B1..2",
+		"R1,C1,M0,S1..2,B1..2",
+		"R1,C0,M2,S1..2,B1..2",
+		"R1,C0,M0,S1..x,B1..2",
+		"R1,C0,M0,B1..2",
+		"R1,R2,S1,B1",
+		"R1,S1,B1,NX",
+		"R1,S1,B1,NW111",
+		"R1,S1,B1,,",
+		"B3/S23",
+	} {
+		if _, err := ParseLtL(in); err == nil {
+			t.Errorf("Expected ParseLtL(%q) to fail, but it succeeded", in)
+		}
+	}
+}
+
+func TestIsLtL(t *testing.T) {
+	for in, want := range map[string]bool{
+		"R5,C0,M1,S34..58,B34..45,NM": true,
+		" r2,s1,b1":                   true,
+		"Bosco":                       true,
+		"B3/S23":                      false,
+		"HighLife":                    false,
+		"Replicator":                  false,
+		"":                            false,
+	} {
+		if got := IsLtL(in); got != want {
+			t.Errorf("Expected IsLtL(%q) to be %v, but got %v", in, want, got)
+		}
+	}
+}
+
+func TestLtLRule_Next(t *testing.T) {
+	r, _ := ParseLtL("R1,C0,M1,S3..4,B3..3,NM")
+	// With M1 a live cell counts itself, so S3..4 is Conway's S23.
+	for n := 0; n <= 8; n++ {
+		if got, want := r.Next(true, n), n == 2 || n == 3; got != want {
+			t.Errorf("Live cell with %d neighbours: got %v, expected %v", n, got, want)
+		}
+		if got, want := r.Next(false, n), n == 3; got != want {
+			t.Errorf("Dead cell with %d neighbours: got %v, expected %v", n, got, want)
+		}
+	}
+}
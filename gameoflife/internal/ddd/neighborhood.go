@@ -0,0 +1,257 @@
+package ddd
+
+import (
+	"SideProjectGames/internal/ddd"
+	"fmt"
+	"strings"
+)
+
+// MaxRadius is the largest neighbourhood radius.
+const MaxRadius = 500
+
+// Neighborhood is the set of cells around a cell that a rule counts, each
+// with a weight. It is stored as weighted rectangles of offsets so that
+// CountAll can sum each one in constant time from a summed-area table: a
+// Moore neighbourhood is a single rectangle and the other shapes one run per
+// row. The centre cell is part of the shape; Count and CountAll leave it out
+// and rules that count it add it back.
+type Neighborhood struct {
+	kind   byte
+	radius int
+	rects  []weightedRect
+	// center is the weight of the centre cell.
+	center int
+	// weights is the mask of a Weighted neighbourhood, row by row.
+	weights []int
+}
+
+// weightedRect covers the offsets (x0..x1, y0..y1), inclusive, with one
+// weight.
+type weightedRect struct {
+	x0, y0, x1, y1 int
+	weight         int
+}
+
+// Moore returns the (2r+1)x(2r+1) square around a cell. Moore(1) is the
+// Game of Life's 8 neighbours.
+func Moore(radius int) Neighborhood {
+	return Neighborhood{kind: 'M', radius: radius, center: 1,
+		rects: []weightedRect{{-radius, -radius, radius, radius, 1}}}
+}
+
+// VonNeumann returns the diamond of cells within a taxicab distance of
+// radius. VonNeumann(1) is the 4 orthogonal neighbours.
+func VonNeumann(radius int) Neighborhood {
+	n := Neighborhood{kind: 'N', radius: radius, center: 1}
+	for dy := -radius; dy <= radius; dy++ {
+		w := radius - abs(dy)
+		n.rects = append(n.rects, weightedRect{-w, dy, w, dy, 1})
+	}
+	return n
+}
+
+// Hexagonal returns the cells within radius steps on a hexagonal grid laid
+// out on the square one, as Golly does: each row is skewed so that the
+// north-east and south-west diagonals are not neighbours. Hexagonal(1) has 6
+// neighbours.
+func Hexagonal(radius int) Neighborhood {
+	n := Neighborhood{kind: 'H', radius: radius, center: 1}
+	for dy := -radius; dy <= radius; dy++ {
+		n.rects = append(n.rects, weightedRect{max(-radius, dy-radius), dy, min(radius, dy+radius), dy, 1})
+	}
+	return n
+}
+
+// Weighted returns a neighbourhood from a square mask of weights with an odd
+// side, centred on the cell. Zero weights leave cells out and negative
+// weights count against the total. Runs of equal weights along a row are
+// summed together.
+func Weighted(mask [][]int) (Neighborhood, error) {
+	side := len(mask)
+	if side%2 == 0 || side > 2*MaxRadius+1 {
+		return Neighborhood{}, fmt.Errorf("weighted neighbourhood: expected an odd number of rows up to %d, but got %d", 2*MaxRadius+1, side)
+	}
+	radius := side / 2
+	n := Neighborhood{kind: 'W', radius: radius, weights: make([]int, 0, side*side)}
+	for i, row := range mask {
+		if len(row) != side {
+			return Neighborhood{}, fmt.Errorf("weighted neighbourhood: row %d has %d weights, expected %d", i, len(row), side)
+		}
+		n.weights = append(n.weights, row...)
+		dy := i - radius
+		for start := 0; start < side; {
+			end := start
+			for end+1 < side && row[end+1] == row[start] {
+				end++
+			}
+			if row[start] != 0 {
+				n.rects = append(n.rects, weightedRect{start - radius, dy, end - radius, dy, row[start]})
+			}
+			start = end + 1
+		}
+	}
+	n.center = mask[radius][radius]
+	return n, nil
+}
+
+// parseNeighborhood reads the N section of Larger-than-Life notation, without
+// the N: M (Moore), N (von Neumann), H (hexagonal) or W followed by
+// (2r+1)^2 hexadecimal weights in reading order.
+func parseNeighborhood(s string, radius int) (Neighborhood, error) {
+	switch {
+	case s == "M":
+		return Moore(radius), nil
+	case s == "N":
+		return VonNeumann(radius), nil
+	case s == "H":
+		return Hexagonal(radius), nil
+	case strings.HasPrefix(s, "W"):
+		digits := s[1:]
+		side := 2*radius + 1
+		if len(digits) != side*side {
+			return Neighborhood{}, fmt.Errorf("weighted neighbourhood of radius %d: expected %d weights, but got %d", radius, side*side, len(digits))
+		}
+		mask := make([][]int, side)
+		for y := range mask {
+			mask[y] = make([]int, side)
+			for x := range mask[y] {
+				var w int
+				if _, err := fmt.Sscanf(digits[y*side+x:y*side+x+1], "%x", &w); err != nil {
+					return Neighborhood{}, fmt.Errorf("weighted neighbourhood: bad weight %q", digits[y*side+x])
+				}
+				mask[y][x] = w
+			}
+		}
+		return Weighted(mask)
+	}
+	return Neighborhood{}, fmt.Errorf("unknown neighbourhood N%s; expected NM, NN, NH or NW followed by weights", s)
+}
+
+// Radius is how far the neighbourhood reaches from the centre.
+func (n Neighborhood) Radius() int {
+	return n.radius
+}
+
+// Center is the weight of the centre cell, for rules that count it.
+func (n Neighborhood) Center() int {
+	return n.center
+}
+
+// Each calls fn with every offset in the neighbourhood and its weight, the
+// centre included.
+func (n Neighborhood) Each(fn func(dx, dy, weight int)) {
+	for _, r := range n.rects {
+		for dy := r.y0; dy <= r.y1; dy++ {
+			for dx := r.x0; dx <= r.x1; dx++ {
+				fn(dx, dy, r.weight)
+			}
+		}
+	}
+}
+
+// MaxCount is the largest total the neighbourhood can count, the centre left
+// out: the sum of its positive weights.
+func (n Neighborhood) MaxCount() int {
+	total := 0
+	n.Each(func(dx, dy, weight int) {
+		if weight > 0 && (dx != 0 || dy != 0) {
+			total += weight
+		}
+	})
+	return total
+}
+
+// Count sums the weights of the live cells around (x, y), the centre left
+// out, one cell at a time. CountAll gives the same counts for a whole board
+// much faster.
+func (n Neighborhood) Count(g ddd.Grid[bool], x int, y int) int {
+	total := 0
+	n.Each(func(dx, dy, weight int) {
+		if (dx != 0 || dy != 0) && g.Coordinate(x+dx, y+dy) {
+			total += weight
+		}
+	})
+	return total
+}
+
+// String is the N section of Larger-than-Life notation, such as "NM".
+func (n Neighborhood) String() string {
+	if n.kind != 'W' {
+		return "N" + string(n.kind)
+	}
+	var sb strings.Builder
+	sb.WriteString("NW")
+	for _, w := range n.weights {
+		if w >= 0 && w < 16 {
+			fmt.Fprintf(&sb, "%x", w)
+		} else {
+			// Weights outside 0-f have no single digit; show them plainly.
+			fmt.Fprintf(&sb, "(%d)", w)
+		}
+	}
+	return sb.String()
+}
+
+// CountAll fills counts, in reading order, with what Count would return for
+// every cell of a cols x rows board. live reads a cell and is called once for
+// every cell of the board and a border radius wide around it, so it decides
+// what lies past the edges. It builds a summed-area table once, after which
+// each rectangle of the neighbourhood costs four lookups whatever its size.
+// The table is built in sat, which is grown when it is too small and returned
+// so that a stepper can pass it back in the next generation; nil is fine the
+// first time.
+func CountAll(n Neighborhood, cols, rows int, live func(x, y int) bool, counts []int, sat []int32) []int32 {
+	r := n.radius
+	width, height := cols+2*r, rows+2*r
+	// sat[(y+1)*(width+1)+(x+1)] is the number of live cells in the padded
+	// board above and left of (x, y), inclusive; row and column 0 are zero.
+	stride := width + 1
+	if size := stride * (height + 1); cap(sat) < size {
+		sat = make([]int32, size)
+	} else {
+		sat = sat[:size]
+		// Only row 0 and column 0 are read before they are written.
+		clear(sat[:stride])
+		for y := 1; y <= height; y++ {
+			sat[y*stride] = 0
+		}
+	}
+	for y := 0; y < height; y++ {
+		var rowSum int32
+		for x := 0; x < width; x++ {
+			if live(x-r, y-r) {
+				rowSum++
+			}
+			sat[(y+1)*stride+x+1] = sat[y*stride+x+1] + rowSum
+		}
+	}
+
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			total := 0
+			for _, rect := range n.rects {
+				// Shift into the padded board, whose (0, 0) is (-r, -r).
+				x0, y0 := x+rect.x0+r, y+rect.y0+r
+				x1, y1 := x+rect.x1+r+1, y+rect.y1+r+1
+				sum := sat[y1*stride+x1] - sat[y0*stride+x1] - sat[y1*stride+x0] + sat[y0*stride+x0]
+				total += int(sum) * rect.weight
+			}
+			if n.center != 0 {
+				// The centre is the 1x1 rectangle at (x+r, y+r).
+				cx, cy := x+r, y+r
+				if sat[(cy+1)*stride+cx+1]-sat[cy*stride+cx+1]-sat[(cy+1)*stride+cx]+sat[cy*stride+cx] != 0 {
+					total -= n.center
+				}
+			}
+			counts[y*cols+x] = total
+		}
+	}
+	return sat
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
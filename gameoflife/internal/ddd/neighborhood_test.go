@@ -0,0 +1,116 @@
+package ddd
+
+import (
+	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"fmt"
+	"testing"
+)
+
+func TestNeighborhood_Sizes(t *testing.T) {
+	cases := []struct {
+		name string
+		n    Neighborhood
+		want int
+	}{
+		{"Moore(1)", Moore(1), 8},
+		{"Moore(2)", Moore(2), 24},
+		{"VonNeumann(1)", VonNeumann(1), 4},
+		{"VonNeumann(2)", VonNeumann(2), 12},
+		{"Hexagonal(1)", Hexagonal(1), 6},
+		{"Hexagonal(2)", Hexagonal(2), 18},
+	}
+	for _, c := range cases {
+		if got := c.n.MaxCount(); got != c.want {
+			t.Errorf("Expected %s to have %d neighbours, but got %d", c.name, c.want, got)
+		}
+	}
+
+	hex := make(map[[2]int]bool)
+	Hexagonal(1).Each(func(dx, dy, _ int) { hex[[2]int{dx, dy}] = true })
+	if hex[[2]int{1, -1}] || hex[[2]int{-1, 1}] || !hex[[2]int{-1, -1}] || !hex[[2]int{1, 1}] {
+		t.Errorf("Expected the hexagonal neighbourhood to skip the north-east and south-west diagonals, but got %v", hex)
+	}
+}
+
+func TestNeighborhood_MooreMatchesCountSurroundingLive(t *testing.T) {
+	b := newGOLBoard(12, 9, ddd.Toroidal)
+	b.SeedBoard(rng.New(3), SeedOptions{Density: 0.4})
+	for y := 0; y < b.Rows(); y++ {
+		for x := 0; x < b.Cols(); x++ {
+			if got, want := Moore(1).Count(b, x, y), b.CountSurroundingLive(x, y); got != want {
+				t.Fatalf("Expected Moore(1) to count %d at (%d, %d), but got %d", want, x, y, got)
+			}
+		}
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	n, err := Weighted([][]int{
+		{1, 2, 1},
+		{2, 5, 2},
+		{1, 2, 1},
+	})
+	if err != nil {
+		t.Fatalf("Expected a 3x3 mask to be accepted, but got %v", err)
+	}
+	if n.Radius() != 1 || n.Center() != 5 || n.MaxCount() != 12 {
+		t.Errorf("Expected radius 1, centre 5 and a count of up to 12, but got %d, %d and %d", n.Radius(), n.Center(), n.MaxCount())
+	}
+	if n.String() != "NW121252121" {
+		t.Errorf("Expected NW121252121, but got %s", n)
+	}
+
+	for _, mask := range [][][]int{{{1, 1}, {1, 1}}, {{1, 1, 1}, {1, 1}, {1, 1, 1}}} {
+		if _, err := Weighted(mask); err == nil {
+			t.Errorf("Expected mask %v to be rejected", mask)
+		}
+	}
+}
+
+// TestCountAll_MatchesCount checks the summed-area table against counting
+// one cell at a time, for every shape and topology and for radii reaching
+// past the board.
+func TestCountAll_MatchesCount(t *testing.T) {
+	weighted, _ := Weighted([][]int{
+		{0, 1, 0, 1, 0},
+		{1, 3, 3, 3, 1},
+		{0, 3, 9, 3, 0},
+		{1, 3, -2, 3, 1},
+		{0, 1, 0, 1, 0},
+	})
+	neighborhoods := []Neighborhood{Moore(1), Moore(3), VonNeumann(2), Hexagonal(3), weighted, Moore(12)}
+	topologies := []ddd.Topology{ddd.Toroidal, ddd.Bounded, ddd.KleinBottle, ddd.ProjectivePlane}
+
+	// One table is reused throughout, shrinking and growing with the radius.
+	var sat []int32
+	for _, topology := range topologies {
+		b := newGOLBoard(17, 11, topology)
+		b.SeedBoard(rng.New(7), SeedOptions{Density: 0.45})
+		counts := make([]int, b.Cols()*b.Rows())
+		for _, n := range neighborhoods {
+			sat = CountAll(n, b.Cols(), b.Rows(), b.Coordinate, counts, sat)
+			for y := 0; y < b.Rows(); y++ {
+				for x := 0; x < b.Cols(); x++ {
+					if want := n.Count(b, x, y); counts[y*b.Cols()+x] != want {
+						t.Fatalf("%s, %s radius %d: expected %d at (%d, %d), but got %d", topology, n, n.Radius(), want, x, y, counts[y*b.Cols()+x])
+					}
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkCountAll(b *testing.B) {
+	board := newGOLBoard(256, 256, ddd.Toroidal)
+	board.SeedBoard(rng.New(1), SeedOptions{Density: 0.5})
+	counts := make([]int, 256*256)
+	var sat []int32
+	for _, n := range []Neighborhood{Moore(1), Moore(10), Moore(50)} {
+		b.Run(fmt.Sprintf("R%d", n.Radius()), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sat = CountAll(n, 256, 256, board.Coordinate, counts, sat)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package ddd
+
+import (
+	"SideProjectGames/internal/ddd"
+	"fmt"
+)
+
+// Pattern is a rectangular block of cells read from or written to a pattern
+// file. Cells is row-major, Width*Height long. Rule is the rule named by the
+// file, if any, in whatever notation the file used.
+type Pattern struct {
+	Name     string
+	Comments []string
+	Rule     string
+	Width    int
+	Height   int
+	Cells    []bool
+}
+
+// MaxPatternCells is the largest pattern, in cells, that the readers accept.
+// Patterns are stored densely, so a file claiming a larger size is rejected
+// before anything is allocated for it.
+const MaxPatternCells = 1 << 26
+
+// checkPatternSize fails when a width x height pattern is negative or larger
+// than MaxPatternCells.
+func checkPatternSize(width int, height int) error {
+	if width < 0 || height < 0 {
+		return fmt.Errorf("invalid pattern size %dx%d", width, height)
+	}
+	if width > 0 && height > MaxPatternCells/width {
+		return fmt.Errorf("pattern is %dx%d, larger than the %d cell limit", width, height, MaxPatternCells)
+	}
+	return nil
+}
+
+// NewPattern returns an empty width x height pattern.
+func NewPattern(width int, height int) *Pattern {
+	return &Pattern{Width: width, Height: height, Cells: make([]bool, width*height)}
+}
+
+// Alive reports whether the cell at (x, y) is alive. Coordinates outside the
+// pattern are dead.
+func (p *Pattern) Alive(x int, y int) bool {
+	if x < 0 || x >= p.Width || y < 0 || y >= p.Height {
+		return false
+	}
+	return p.Cells[y*p.Width+x]
+}
+
+// Set changes the cell at (x, y); coordinates outside the pattern are ignored.
+func (p *Pattern) Set(x int, y int, alive bool) {
+	if x < 0 || x >= p.Width || y < 0 || y >= p.Height {
+		return
+	}
+	p.Cells[y*p.Width+x] = alive
+}
+
+// Population counts the live cells.
+func (p *Pattern) Population() int {
+	n := 0
+	for _, alive := range p.Cells {
+		if alive {
+			n++
+		}
+	}
+	return n
+}
+
+// Place copies the live cells of the pattern onto b with its top-left corner at
+// (offsetX, offsetY). Dead cells leave the board untouched. Coordinates follow
+// the board's topology, so cells past the edge of a bounded board are dropped.
+func (p *Pattern) Place(b ddd.Grid[bool], offsetX int, offsetY int) {
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			if p.Cells[y*p.Width+x] {
+				b.SetCoordinate(offsetX+x, offsetY+y, true)
+			}
+		}
+	}
+}
+
+// PlaceCentered places the pattern in the middle of b. It fails when the
+// pattern does not fit on the board.
+func (p *Pattern) PlaceCentered(b GolBoard) error {
+	if p.Width > b.Cols() || p.Height > b.Rows() {
+		return fmt.Errorf("pattern is %dx%d but the board is only %dx%d", p.Width, p.Height, b.Cols(), b.Rows())
+	}
+	p.Place(b, (b.Cols()-p.Width)/2, (b.Rows()-p.Height)/2)
+	return nil
+}
+
+// PatternFromBoard copies the width x height region of b starting at (x, y).
+func PatternFromBoard(b GolBoard, x int, y int, width int, height int) *Pattern {
+	p := NewPattern(width, height)
+	for dy := 0; dy < height; dy++ {
+		for dx := 0; dx < width; dx++ {
+			p.Cells[dy*width+dx] = b.Coordinate(x+dx, y+dy)
+		}
+	}
+	return p
+}
+
+// Trim returns a copy of the pattern cropped to the bounding box of its live
+// cells. An empty pattern trims to 0x0.
+func (p *Pattern) Trim() *Pattern {
+	minX, minY, maxX, maxY := p.Width, p.Height, -1, -1
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			if p.Cells[y*p.Width+x] {
+				minX, maxX = min(minX, x), max(maxX, x)
+				minY, maxY = min(minY, y), max(maxY, y)
+			}
+		}
+	}
+
+	out := NewPattern(max(0, maxX-minX+1), max(0, maxY-minY+1))
+	out.Name, out.Comments, out.Rule = p.Name, p.Comments, p.Rule
+	for y := 0; y < out.Height; y++ {
+		for x := 0; x < out.Width; x++ {
+			out.Cells[y*out.Width+x] = p.Cells[(y+minY)*p.Width+x+minX]
+		}
+	}
+	return out
+}
+
+// Region returns the pattern's cells as a generic region, sharing storage, so
+// the ddd region helpers can transform and stamp it.
+func (p *Pattern) Region() *ddd.Region[bool] {
+	return &ddd.Region[bool]{Width: p.Width, Height: p.Height, Cells: p.Cells}
+}
+
+// PatternFromRegion wraps a region of cells as a pattern, sharing storage.
+func PatternFromRegion(r *ddd.Region[bool]) *Pattern {
+	return &Pattern{Width: r.Width, Height: r.Height, Cells: r.Cells}
+}
@@ -0,0 +1,89 @@
+package ddd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadPlaintext parses a pattern in the plaintext (.cells) format: "!" comment
+// lines followed by rows where "." is dead and "O" is alive. Rows may be
+// shorter than the widest row; missing cells are dead. A pattern over
+// MaxPatternCells is an error.
+func ReadPlaintext(r io.Reader) (*Pattern, error) {
+	scanner := bufio.NewScanner(r)
+	p := &Pattern{}
+	var rows []string
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.HasPrefix(line, "!") {
+			comment := strings.TrimSpace(line[1:])
+			if name, ok := strings.CutPrefix(comment, "Name:"); ok {
+				p.Name = strings.TrimSpace(name)
+			} else if rule, ok := strings.CutPrefix(comment, "Rule:"); ok {
+				p.Rule = strings.TrimSpace(rule)
+			} else if comment != "" {
+				p.Comments = append(p.Comments, comment)
+			}
+			continue
+		}
+		rows = append(rows, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Blank lines at the end of the file are not part of the pattern.
+	for len(rows) > 0 && rows[len(rows)-1] == "" {
+		rows = rows[:len(rows)-1]
+	}
+
+	for _, row := range rows {
+		p.Width = max(p.Width, len(row))
+	}
+	p.Height = len(rows)
+	if err := checkPatternSize(p.Width, p.Height); err != nil {
+		return nil, fmt.Errorf("plaintext: %w", err)
+	}
+	p.Cells = make([]bool, p.Width*p.Height)
+	for y, row := range rows {
+		for x, ch := range []byte(row) {
+			switch ch {
+			case 'O', 'o', '*':
+				p.Cells[y*p.Width+x] = true
+			case '.':
+			default:
+				return nil, fmt.Errorf("plaintext: unexpected character %q on row %d", ch, y+1)
+			}
+		}
+	}
+	return p, nil
+}
+
+// WritePlaintext encodes p in the plaintext (.cells) format.
+func WritePlaintext(w io.Writer, p *Pattern) error {
+	bw := bufio.NewWriter(w)
+	if p.Name != "" {
+		fmt.Fprintf(bw, "!Name: %s\n", p.Name)
+	}
+	for _, c := range p.Comments {
+		fmt.Fprintf(bw, "!%s\n", c)
+	}
+	if p.Rule != "" && p.Rule != Conway.String() {
+		fmt.Fprintf(bw, "!Rule: %s\n", p.Rule)
+	}
+	row := make([]byte, p.Width)
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			row[x] = '.'
+			if p.Cells[y*p.Width+x] {
+				row[x] = 'O'
+			}
+		}
+		bw.Write(row)
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
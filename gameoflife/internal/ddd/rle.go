@@ -0,0 +1,225 @@
+package ddd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// rleLineLength is the maximum body line length recommended by the RLE format.
+const rleLineLength = 70
+
+// ReadRLE parses a pattern in Run Length Encoded format: optional "#" comment
+// lines, an "x = m, y = n, rule = B3/S23" header and a body of runs of b (dead),
+// o (alive) and $ (end of row), terminated by "!".
+func ReadRLE(r io.Reader) (*Pattern, error) {
+	scanner := bufio.NewScanner(r)
+	p := &Pattern{}
+	headerSeen := false
+	var body strings.Builder
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case !headerSeen && strings.HasPrefix(line, "#"):
+			readRLEComment(p, line)
+		case !headerSeen:
+			if err := readRLEHeader(p, line); err != nil {
+				return nil, err
+			}
+			headerSeen = true
+		default:
+			body.WriteString(line)
+			if strings.Contains(line, "!") {
+				return p, readRLEBody(p, body.String())
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !headerSeen {
+		return nil, fmt.Errorf("rle: missing \"x = m, y = n\" header")
+	}
+	// Some writers leave out the final "!"; accept whatever body we have.
+	return p, readRLEBody(p, body.String())
+}
+
+func readRLEComment(p *Pattern, line string) {
+	if len(line) < 2 {
+		return
+	}
+	rest := strings.TrimSpace(line[2:])
+	switch line[1] {
+	case 'N':
+		p.Name = rest
+	case 'r':
+		p.Rule = rest
+	default:
+		p.Comments = append(p.Comments, rest)
+	}
+}
+
+func readRLEHeader(p *Pattern, line string) error {
+	for _, field := range strings.Split(line, ",") {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("rle: malformed header field %q", strings.TrimSpace(field))
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		switch key {
+		case "x", "y":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return fmt.Errorf("rle: invalid %s dimension %q", key, value)
+			}
+			if key == "x" {
+				p.Width = n
+			} else {
+				p.Height = n
+			}
+		case "rule":
+			p.Rule = value
+		}
+	}
+	if err := checkPatternSize(p.Width, p.Height); err != nil {
+		return fmt.Errorf("rle: %w", err)
+	}
+	p.Cells = make([]bool, p.Width*p.Height)
+	return nil
+}
+
+func readRLEBody(p *Pattern, body string) error {
+	x, y, count := 0, 0, 0
+	for _, ch := range body {
+		switch {
+		case ch >= '0' && ch <= '9':
+			count = count*10 + int(ch-'0')
+			continue
+		case ch == '!':
+			return nil
+		case ch == '$':
+			y += max(count, 1)
+			x = 0
+		case ch == 'b' || ch == '.':
+			x += max(count, 1)
+		case ch == 'o' || (ch >= 'A' && ch <= 'X'):
+			// Multi-state letters are treated as alive.
+			for i := 0; i < max(count, 1); i++ {
+				if x >= p.Width || y >= p.Height {
+					return fmt.Errorf("rle: cell (%d, %d) outside the %dx%d header size", x, y, p.Width, p.Height)
+				}
+				p.Cells[y*p.Width+x] = true
+				x++
+			}
+		case ch == ' ' || ch == '\t':
+		default:
+			return fmt.Errorf("rle: unexpected character %q in pattern body", ch)
+		}
+		count = 0
+	}
+	return nil
+}
+
+// WriteRLE encodes p in Run Length Encoded format. Trailing dead cells on each
+// row and trailing empty rows are omitted, as other Life tools expect.
+func WriteRLE(w io.Writer, p *Pattern) error {
+	bw := bufio.NewWriter(w)
+	if p.Name != "" {
+		fmt.Fprintf(bw, "#N %s\n", p.Name)
+	}
+	for _, c := range p.Comments {
+		fmt.Fprintf(bw, "#C %s\n", c)
+	}
+	if p.Rule != "" {
+		fmt.Fprintf(bw, "x = %d, y = %d, rule = %s\n", p.Width, p.Height, p.Rule)
+	} else {
+		fmt.Fprintf(bw, "x = %d, y = %d\n", p.Width, p.Height)
+	}
+
+	lw := &rleLineWriter{w: bw}
+	lastRow := 0
+	for y := 0; y < p.Height; y++ {
+		runs := rleRowRuns(p, y)
+		if len(runs) == 0 {
+			continue
+		}
+		if y > lastRow {
+			lw.token(y-lastRow, '$')
+			lastRow = y
+		}
+		for _, r := range runs {
+			lw.token(r.count, r.tag)
+		}
+	}
+	lw.token(1, '!')
+	bw.WriteByte('\n')
+	return bw.Flush()
+}
+
+type rleRun struct {
+	count int
+	tag   byte
+}
+
+// rleRowRuns returns the runs of row y with the trailing dead run dropped.
+func rleRowRuns(p *Pattern, y int) []rleRun {
+	var runs []rleRun
+	for x := 0; x < p.Width; x++ {
+		tag := byte('b')
+		if p.Cells[y*p.Width+x] {
+			tag = 'o'
+		}
+		if n := len(runs); n > 0 && runs[n-1].tag == tag {
+			runs[n-1].count++
+		} else {
+			runs = append(runs, rleRun{count: 1, tag: tag})
+		}
+	}
+	if n := len(runs); n > 0 && runs[n-1].tag == 'b' {
+		runs = runs[:n-1]
+	}
+	return runs
+}
+
+// rleLineWriter wraps body tokens so no line exceeds rleLineLength.
+type rleLineWriter struct {
+	w      *bufio.Writer
+	column int
+}
+
+func (lw *rleLineWriter) token(count int, tag byte) {
+	tok := string(tag)
+	if count > 1 {
+		tok = strconv.Itoa(count) + tok
+	}
+	if lw.column+len(tok) > rleLineLength {
+		lw.w.WriteByte('\n')
+		lw.column = 0
+	}
+	lw.w.WriteString(tok)
+	lw.column += len(tok)
+}
+
+// LoadRLE reads an RLE pattern from r and places it on b at (offsetX, offsetY).
+func LoadRLE(b GolBoard, r io.Reader, offsetX int, offsetY int) (*Pattern, error) {
+	p, err := ReadRLE(r)
+	if err != nil {
+		return nil, err
+	}
+	p.Place(b, offsetX, offsetY)
+	return p, nil
+}
+
+// SaveRLE writes the width x height region of b starting at (x, y) as RLE.
+// Pass 0, 0, b.Cols(), b.Rows() to export the whole board.
+func SaveRLE(w io.Writer, b GolBoard, rule Rule, x int, y int, width int, height int) error {
+	p := PatternFromBoard(b, x, y, width, height)
+	p.Rule = rule.String()
+	return WriteRLE(w, p)
+}
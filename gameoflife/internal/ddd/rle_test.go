@@ -0,0 +1,117 @@
+package ddd
+
+import (
+	"SideProjectGames/internal/ddd"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const gliderGunRLE = `#N Gosper glider gun
+#C This was the first gun discovered.
+x = 36, y = 9, rule = B3/S23
+24bo$22bobo$12b2o6b2o12b2o$11bo3bo4b2o12b2o$2o8bo5bo3b2o$2o8bo3bob2o4b
+obo$10bo5bo7bo$11bo3bo$12b2o!
+`
+
+func TestReadRLE_GliderGun(t *testing.T) {
+	p, err := ReadRLE(strings.NewReader(gliderGunRLE))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if p.Name != "Gosper glider gun" {
+		t.Errorf("Expected name to be read from #N, but got %q", p.Name)
+	}
+	if p.Width != 36 || p.Height != 9 || p.Rule != "B3/S23" {
+		t.Errorf("Expected 36x9 B3/S23, but got %dx%d %s", p.Width, p.Height, p.Rule)
+	}
+	if got := p.Population(); got != 36 {
+		t.Errorf("Expected 36 live cells, but got %d", got)
+	}
+	if !p.Alive(24, 0) || p.Alive(23, 0) || !p.Alive(0, 4) || !p.Alive(13, 8) {
+		t.Error("Expected cells to be decoded at the right positions")
+	}
+}
+
+func TestWriteRLE_RoundTrip(t *testing.T) {
+	p, err := ReadRLE(strings.NewReader(gliderGunRLE))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRLE(&buf, p); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if len(line) > rleLineLength {
+			t.Errorf("Expected lines of at most %d characters, but got %q", rleLineLength, line)
+		}
+	}
+
+	again, err := ReadRLE(&buf)
+	if err != nil {
+		t.Fatalf("Expected written RLE to parse, but got %v", err)
+	}
+	if again.Width != p.Width || again.Height != p.Height {
+		t.Fatalf("Expected %dx%d after round trip, but got %dx%d", p.Width, p.Height, again.Width, again.Height)
+	}
+	for i := range p.Cells {
+		if p.Cells[i] != again.Cells[i] {
+			t.Fatalf("Cell %d differs after round trip", i)
+		}
+	}
+}
+
+func TestWriteRLE_SkipsEmptyRows(t *testing.T) {
+	p := NewPattern(3, 4)
+	p.Set(1, 1, true)
+	p.Set(0, 3, true)
+
+	var buf bytes.Buffer
+	if err := WriteRLE(&buf, p); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want := "x = 3, y = 4\n$bo2$o!\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, but got %q", want, buf.String())
+	}
+}
+
+func TestLoadRLE_PlacesAtOffset(t *testing.T) {
+	board := newGOLBoard(10, 10, ddd.Toroidal)
+	glider := "x = 3, y = 3\nbo$2bo$3o!"
+
+	if _, err := LoadRLE(board, strings.NewReader(glider), 4, 5); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	for _, c := range [][2]int{{5, 5}, {6, 6}, {4, 7}, {5, 7}, {6, 7}} {
+		if !board.Coordinate(c[0], c[1]) {
+			t.Errorf("Expected (%d, %d) to be alive", c[0], c[1])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := SaveRLE(&buf, board, Conway, 4, 5, 3, 3); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if want := "x = 3, y = 3, rule = B3/S23\nbo$2bo$3o!\n"; buf.String() != want {
+		t.Errorf("Expected region export %q, but got %q", want, buf.String())
+	}
+}
+
+func TestReadRLE_Errors(t *testing.T) {
+	for _, in := range []string{"bo$2bo$3o!", "x = 2, y = 1\n3o!", "x = 3, y = 3\nbqo!"} {
+		if _, err := ReadRLE(strings.NewReader(in)); err == nil {
+			t.Errorf("Expected ReadRLE(%q) to fail, but it succeeded", in)
+		}
+	}
+}
+
+func TestReadRLE_RejectsHugeHeader(t *testing.T) {
+	for _, in := range []string{"x = 100000000, y = 100000000\no!", "x = 9223372036854775807, y = 2\no!"} {
+		if _, err := ReadRLE(strings.NewReader(in)); err == nil {
+			t.Errorf("Expected ReadRLE(%q) to refuse the size, but it succeeded", in)
+		}
+	}
+}
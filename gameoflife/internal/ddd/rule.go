@@ -0,0 +1,166 @@
+package ddd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule is a Life-like rule: whether a cell is born or survives depends only on
+// how many of its 8 neighbors are alive. Bit n of birth/survive is set when a
+// count of n neighbors causes a birth/survival.
+type Rule struct {
+	birth   uint16
+	survive uint16
+}
+
+// Conway is the standard Game of Life rule, B3/S23.
+var Conway = Rule{birth: 1 << 3, survive: 1<<2 | 1<<3}
+
+// namedRules maps well-known rule names to their B/S strings.
+var namedRules = map[string]string{
+	"CONWAY":           "B3/S23",
+	"LIFE":             "B3/S23",
+	"HIGHLIFE":         "B36/S23",
+	"DAYANDNIGHT":      "B3678/S34678",
+	"DAYNIGHT":         "B3678/S34678",
+	"SEEDS":            "B2/S",
+	"LIFEWITHOUTDEATH": "B3/S012345678",
+	"DIAMOEBA":         "B35678/S5678",
+	"MAZE":             "B3/S12345",
+	"REPLICATOR":       "B1357/S1357",
+	"2X2":              "B36/S125",
+	"MOVE":             "B368/S245",
+}
+
+// NewRule builds a rule from neighbor counts (0-8) that cause a birth and survival.
+func NewRule(birth []int, survive []int) (Rule, error) {
+	var r Rule
+	for _, n := range birth {
+		if n < 0 || n > 8 {
+			return Rule{}, fmt.Errorf("birth count %d out of range 0-8", n)
+		}
+		r.birth |= 1 << n
+	}
+	for _, n := range survive {
+		if n < 0 || n > 8 {
+			return Rule{}, fmt.Errorf("survival count %d out of range 0-8", n)
+		}
+		r.survive |= 1 << n
+	}
+	return r, nil
+}
+
+// ParseRule reads a rule in B/S notation ("B36/S23", case-insensitive, either
+// order), the older S/B notation ("23/3", survival first) or one of the
+// well-known names such as "HighLife" or "Day & Night".
+func ParseRule(s string) (Rule, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return Conway, nil
+	}
+
+	key := strings.NewReplacer(" ", "", "&", "AND", "_", "", "-", "").Replace(strings.ToUpper(trimmed))
+	if named, ok := namedRules[key]; ok {
+		trimmed = named
+	}
+
+	upper := strings.ToUpper(strings.ReplaceAll(trimmed, " ", ""))
+	if strings.ContainsAny(upper, "BS") {
+		return parseBS(upper, s)
+	}
+	return parseSB(upper, s)
+}
+
+// parseBS handles "B3/S23", "S23/B3" and the slash-less "B3S23".
+func parseBS(upper string, original string) (Rule, error) {
+	var r Rule
+	var target *uint16
+	seenB, seenS := false, false
+	for _, ch := range upper {
+		switch {
+		case ch == 'B':
+			if seenB {
+				return Rule{}, fmt.Errorf("rule %q: B given twice", original)
+			}
+			seenB = true
+			target = &r.birth
+		case ch == 'S':
+			if seenS {
+				return Rule{}, fmt.Errorf("rule %q: S given twice", original)
+			}
+			seenS = true
+			target = &r.survive
+		case ch == '/':
+			target = nil
+		case ch >= '0' && ch <= '8':
+			if target == nil {
+				return Rule{}, fmt.Errorf("rule %q: digit %c outside a B or S section", original, ch)
+			}
+			*target |= 1 << (ch - '0')
+		default:
+			return Rule{}, fmt.Errorf("rule %q: unexpected character %q", original, ch)
+		}
+	}
+	if !seenB || !seenS {
+		return Rule{}, fmt.Errorf("rule %q: expected both a B and an S section", original)
+	}
+	return r, nil
+}
+
+// parseSB handles the survival-first "23/3" notation.
+func parseSB(upper string, original string) (Rule, error) {
+	parts := strings.Split(upper, "/")
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("rule %q: expected B/S (B3/S23) or S/B (23/3) notation", original)
+	}
+	var r Rule
+	for i, part := range parts {
+		for _, ch := range part {
+			if ch < '0' || ch > '8' {
+				return Rule{}, fmt.Errorf("rule %q: unexpected character %q", original, ch)
+			}
+			if i == 0 {
+				r.survive |= 1 << (ch - '0')
+			} else {
+				r.birth |= 1 << (ch - '0')
+			}
+		}
+	}
+	return r, nil
+}
+
+// Next returns the state of a cell in the next generation.
+func (r Rule) Next(alive bool, neighbors int) bool {
+	if alive {
+		return r.survive&(1<<neighbors) != 0
+	}
+	return r.birth&(1<<neighbors) != 0
+}
+
+// Born reports whether a dead cell with n live neighbors comes alive.
+func (r Rule) Born(n int) bool {
+	return r.birth&(1<<n) != 0
+}
+
+// Survives reports whether a live cell with n live neighbors stays alive.
+func (r Rule) Survives(n int) bool {
+	return r.survive&(1<<n) != 0
+}
+
+// String returns the canonical B/S notation, e.g. "B36/S23".
+func (r Rule) String() string {
+	var sb strings.Builder
+	sb.WriteByte('B')
+	for n := 0; n <= 8; n++ {
+		if r.Born(n) {
+			sb.WriteByte(byte('0' + n))
+		}
+	}
+	sb.WriteString("/S")
+	for n := 0; n <= 8; n++ {
+		if r.Survives(n) {
+			sb.WriteByte(byte('0' + n))
+		}
+	}
+	return sb.String()
+}
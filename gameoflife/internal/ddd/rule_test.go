@@ -0,0 +1,50 @@
+package ddd
+
+import "testing"
+
+func TestParseRule_Notations(t *testing.T) {
+	cases := map[string]string{
+		"":                 "B3/S23",
+		"B3/S23":           "B3/S23",
+		"b36/s23":          "B36/S23",
+		"S23/B3":           "B3/S23",
+		"B3S23":            "B3/S23",
+		"23/3":             "B3/S23",
+		"34678/3678":       "B3678/S34678",
+		"B2/S":             "B2/S",
+		"/2":               "B2/S",
+		"HighLife":         "B36/S23",
+		"Day & Night":      "B3678/S34678",
+		"seeds":            "B2/S",
+		"LifeWithoutDeath": "B3/S012345678",
+	}
+	for in, want := range cases {
+		r, err := ParseRule(in)
+		if err != nil {
+			t.Errorf("ParseRule(%q) returned error %v", in, err)
+			continue
+		}
+		if r.String() != want {
+			t.Errorf("ParseRule(%q) = %s; expected %s", in, r, want)
+		}
+	}
+}
+
+func TestParseRule_Invalid(t *testing.T) {
+	for _, in := range []string{"B9/S23", "B3", "B3/S2/B4", "hello", "23/3/1", "B3/S2x"} {
+		if _, err := ParseRule(in); err == nil {
+			t.Errorf("Expected ParseRule(%q) to fail, but it succeeded", in)
+		}
+	}
+}
+
+func TestRule_NextMatchesConway(t *testing.T) {
+	for n := 0; n <= 8; n++ {
+		if got, want := Conway.Next(true, n), n == 2 || n == 3; got != want {
+			t.Errorf("Live cell with %d neighbors: got %v, expected %v", n, got, want)
+		}
+		if got, want := Conway.Next(false, n), n == 3; got != want {
+			t.Errorf("Dead cell with %d neighbors: got %v, expected %v", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package ddd
+
+import (
+	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Symmetry is the symmetry a random soup is given.
+type Symmetry uint8
+
+const (
+	// Asymmetric soups draw every cell independently.
+	Asymmetric Symmetry = iota
+	// C2 soups look the same after a half turn.
+	C2
+	// C4 soups look the same after a quarter turn.
+	C4
+	// D8 soups look the same after any quarter turn or reflection.
+	D8
+)
+
+var symmetryNames = map[Symmetry]string{
+	Asymmetric: "NONE",
+	C2:         "C2",
+	C4:         "C4",
+	D8:         "D8",
+}
+
+func (s Symmetry) String() string {
+	if name, ok := symmetryNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Symmetry(%d)", uint8(s))
+}
+
+// ParseSymmetry reads NONE (or C1, or empty), C2, C4 or D8, case-insensitively.
+func ParseSymmetry(s string) (Symmetry, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "", "NONE", "C1":
+		return Asymmetric, nil
+	case "C2":
+		return C2, nil
+	case "C4":
+		return C4, nil
+	case "D8":
+		return D8, nil
+	}
+	return Asymmetric, fmt.Errorf("unknown symmetry %q; expected NONE, C2, C4 or D8", s)
+}
+
+// SeedOptions describe a random soup.
+type SeedOptions struct {
+	// Density is the chance of each cell starting alive.
+	Density float64
+	// X, Y, Width and Height are the region seeded. On a fixed board a zero
+	// Width or Height means the whole board.
+	X, Y          int
+	Width, Height int
+	Symmetry      Symmetry
+}
+
+// DefaultSeedOptions fill the whole board at half density.
+var DefaultSeedOptions = SeedOptions{Density: 0.5}
+
+// ParseRegion reads a seeding region written "x,y,width,height". An empty
+// string is the zero region, which means the whole board.
+func ParseRegion(s string) (x, y, width, height int, err error) {
+	if strings.TrimSpace(s) == "" {
+		return 0, 0, 0, 0, nil
+	}
+	fields := strings.Split(s, ",")
+	if len(fields) != 4 {
+		return 0, 0, 0, 0, fmt.Errorf("region %q: expected x,y,width,height", s)
+	}
+	var n [4]int
+	for i, field := range fields {
+		if n[i], err = strconv.Atoi(strings.TrimSpace(field)); err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("region %q: %w", s, err)
+		}
+	}
+	if n[2] <= 0 || n[3] <= 0 {
+		return 0, 0, 0, 0, fmt.Errorf("region %q: width and height must be positive", s)
+	}
+	return n[0], n[1], n[2], n[3], nil
+}
+
+// Seed writes a random soup into the region of g described by opts, drawing
+// from r. Symmetric soups draw one cell per orbit and copy it to the rest, in
+// reading order, so the same seed always gives the same soup. C4 and D8 need a
+// square, so they seed the largest square centred in the region.
+func Seed(g ddd.Grid[bool], r rng.Source, opts SeedOptions) {
+	x0, y0, width, height := opts.X, opts.Y, opts.Width, opts.Height
+	if opts.Symmetry == C4 || opts.Symmetry == D8 {
+		n := min(width, height)
+		x0, y0 = x0+(width-n)/2, y0+(height-n)/2
+		width, height = n, n
+	}
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	done := make([]bool, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if done[y*width+x] {
+				continue
+			}
+			alive := r.Float64() < opts.Density
+			for _, c := range orbit(opts.Symmetry, x, y, width, height) {
+				done[c[1]*width+c[0]] = true
+				g.SetCoordinate(x0+c[0], y0+c[1], alive)
+			}
+		}
+	}
+}
+
+// orbit returns the cells symmetry maps (x, y) to in a width x height region.
+// C4 and D8 assume the region is square.
+func orbit(symmetry Symmetry, x, y, width, height int) [][2]int {
+	mx, my := width-1-x, height-1-y
+	switch symmetry {
+	case C2:
+		return [][2]int{{x, y}, {mx, my}}
+	case C4:
+		return [][2]int{{x, y}, {my, x}, {mx, my}, {y, mx}}
+	case D8:
+		return [][2]int{{x, y}, {my, x}, {mx, my}, {y, mx}, {mx, y}, {x, my}, {y, x}, {my, mx}}
+	}
+	return [][2]int{{x, y}}
+}
@@ -0,0 +1,149 @@
+package ddd
+
+import (
+	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"slices"
+	"testing"
+)
+
+func TestSeedBoard_IsReproducible(t *testing.T) {
+	for name, newBoard := range golBoardKinds {
+		for _, opts := range []SeedOptions{DefaultSeedOptions, {Density: 0.3, Symmetry: C2}} {
+			a, b, c := newBoard(70, 40, ddd.Toroidal), newBoard(70, 40, ddd.Toroidal), newBoard(70, 40, ddd.Toroidal)
+			a.SeedBoard(rng.New(5), opts)
+			b.SeedBoard(rng.New(5), opts)
+			c.SeedBoard(rng.New(6), opts)
+			if !slices.Equal(a.FlatSlice(), b.FlatSlice()) {
+				t.Errorf("%s %+v: expected the same seed to give the same soup", name, opts)
+			}
+			if slices.Equal(a.FlatSlice(), c.FlatSlice()) {
+				t.Errorf("%s %+v: expected another seed to give another soup", name, opts)
+			}
+		}
+	}
+}
+
+func TestSeedBoard_SameSoupOnEveryKind(t *testing.T) {
+	for _, opts := range []SeedOptions{
+		DefaultSeedOptions,
+		{Density: 0.3},
+		{Density: 0.5, Symmetry: D8},
+		{Density: 0.5, X: 3, Y: 2, Width: 20, Height: 10},
+	} {
+		want := NewGOLBoard(70, 40, ddd.Toroidal)
+		want.SeedBoard(rng.New(9), opts)
+		for name, newBoard := range golBoardKinds {
+			board := newBoard(70, 40, ddd.Toroidal)
+			board.SeedBoard(rng.New(9), opts)
+			if !slices.Equal(board.FlatSlice(), want.FlatSlice()) {
+				t.Errorf("%s %+v: expected the same soup as a BOOL board from the same seed", name, opts)
+			}
+		}
+	}
+}
+
+func TestSeedBoard_DensityAndRegion(t *testing.T) {
+	for name, newBoard := range golBoardKinds {
+		board := newBoard(30, 20, ddd.Toroidal)
+		board.CopyBoard(slices.Repeat([]bool{true}, 30*20))
+		board.SeedBoard(rng.New(1), SeedOptions{Density: 1, X: 5, Y: 4, Width: 10, Height: 3})
+		for y := 0; y < 20; y++ {
+			for x := 0; x < 30; x++ {
+				inside := x >= 5 && x < 15 && y >= 4 && y < 7
+				if board.Coordinate(x, y) != inside {
+					t.Errorf("%s: expected (%d, %d) alive: %v", name, x, y, inside)
+				}
+			}
+		}
+
+		board.SeedBoard(rng.New(1), SeedOptions{Density: 0})
+		if got := countLive(board.FlatSlice()); got != 0 {
+			t.Errorf("%s: expected an empty board at density 0, but got %d cells", name, got)
+		}
+
+		board = newBoard(200, 200, ddd.Toroidal)
+		board.SeedBoard(rng.New(1), SeedOptions{Density: 0.2})
+		if got := countLive(board.FlatSlice()); got < 7500 || got > 8500 {
+			t.Errorf("%s: expected about 8000 cells at density 0.2, but got %d", name, got)
+		}
+	}
+}
+
+func TestSeed_Symmetry(t *testing.T) {
+	tests := []struct {
+		symmetry   Symmetry
+		transforms []func(*ddd.Region[bool]) *ddd.Region[bool]
+	}{
+		{C2, []func(*ddd.Region[bool]) *ddd.Region[bool]{
+			func(r *ddd.Region[bool]) *ddd.Region[bool] { return r.Rotate().Rotate() },
+		}},
+		{C4, []func(*ddd.Region[bool]) *ddd.Region[bool]{
+			(*ddd.Region[bool]).Rotate,
+		}},
+		{D8, []func(*ddd.Region[bool]) *ddd.Region[bool]{
+			(*ddd.Region[bool]).Rotate,
+			(*ddd.Region[bool]).FlipHorizontal,
+			(*ddd.Region[bool]).FlipVertical,
+		}},
+	}
+	for _, tt := range tests {
+		board := newGOLBoard(40, 40, ddd.Bounded)
+		// C4 and D8 use the 11x11 square centred in the 15x11 region.
+		board.SeedBoard(rng.New(3), SeedOptions{Density: 0.5, X: 2, Y: 3, Width: 15, Height: 11, Symmetry: tt.symmetry})
+
+		x, width := 2, 15
+		if tt.symmetry != C2 {
+			x, width = 4, 11
+		}
+		soup := ddd.Extract[bool](board, x, 3, width, 11)
+		if got := countLive(board.FlatSlice()); got != countLive(soup.Cells) || got == 0 {
+			t.Errorf("%v: expected every live cell inside the soup, but got %d of %d", tt.symmetry, countLive(soup.Cells), got)
+		}
+		for i, transform := range tt.transforms {
+			if !slices.Equal(transform(soup).Cells, soup.Cells) {
+				t.Errorf("%v: expected the soup to survive transform %d unchanged", tt.symmetry, i)
+			}
+		}
+		if tt.symmetry == C2 && slices.Equal(soup.FlipHorizontal().Cells, soup.Cells) {
+			t.Error("Expected a C2 soup not to be mirror symmetric")
+		}
+	}
+}
+
+func TestSparseBoard_SeedRegion(t *testing.T) {
+	a, b := NewSparseBoard(), NewSparseBoard()
+	opts := SeedOptions{Density: 0.5, X: -10, Y: -10, Width: 20, Height: 20, Symmetry: D8}
+	a.SeedRegion(rng.New(9), opts)
+	b.SeedRegion(rng.New(9), opts)
+	if a.Population() == 0 || a.Population() != b.Population() {
+		t.Errorf("Expected the same seed to give the same soup, but got %d and %d cells", a.Population(), b.Population())
+	}
+	minX, minY, maxX, maxY, _ := a.Bounds()
+	if minX < -10 || minY < -10 || maxX > 9 || maxY > 9 {
+		t.Errorf("Expected the soup inside the region, but got (%d, %d)-(%d, %d)", minX, minY, maxX, maxY)
+	}
+}
+
+func TestParseSymmetryAndRegion(t *testing.T) {
+	for input, want := range map[string]Symmetry{"": Asymmetric, "none": Asymmetric, "c2": C2, " C4 ": C4, "D8": D8} {
+		if got, err := ParseSymmetry(input); err != nil || got != want {
+			t.Errorf("Expected %q to parse as %v, but got %v, %v", input, want, got, err)
+		}
+	}
+	if _, err := ParseSymmetry("D4"); err == nil {
+		t.Error("Expected an error for an unknown symmetry")
+	}
+
+	if x, y, w, h, err := ParseRegion("10, -5, 20,30"); err != nil || x != 10 || y != -5 || w != 20 || h != 30 {
+		t.Errorf("Expected 20x30 at (10, -5), but got %dx%d at (%d, %d), %v", w, h, x, y, err)
+	}
+	if _, _, w, h, err := ParseRegion(""); err != nil || w != 0 || h != 0 {
+		t.Errorf("Expected an empty region for an empty string, but got %dx%d, %v", w, h, err)
+	}
+	for _, bad := range []string{"1,2,3", "a,b,c,d", "0,0,0,5"} {
+		if _, _, _, _, err := ParseRegion(bad); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package ddd
+
+import (
+	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"math/bits"
+	"strings"
+)
+
+// TileSize is the width and height of the tiles a SparseBoard stores. Each
+// tile row is one uint64 with bit x set when column x is alive.
+const (
+	TileSize  = 1 << tileShift
+	tileShift = 6
+)
+
+// Tile is one TileSize x TileSize block of a SparseBoard.
+type Tile [TileSize]uint64
+
+// TileKey addresses a tile; tile (tx, ty) covers cells
+// [tx*TileSize, (tx+1)*TileSize) x [ty*TileSize, (ty+1)*TileSize).
+type TileKey struct {
+	X, Y int
+}
+
+// SparseBoard is an unbounded Game of Life board. Only tiles holding live
+// cells are stored, so patterns can grow forever without wrapping around.
+type SparseBoard interface {
+	ddd.Grid[bool]
+	CountSurroundingLive(x int, y int) int
+	Population() int
+	// Bounds returns the bounding box of the live cells, inclusive.
+	Bounds() (minX, minY, maxX, maxY int, ok bool)
+	EachLive(fn func(x, y int))
+	Tiles() []TileKey
+	Tile(key TileKey) (Tile, bool)
+	SetTile(key TileKey, tile Tile)
+	Clear()
+	// SeedRegion adds a random soup drawn from r in the region opts
+	// describes, which must not be empty.
+	SeedRegion(r rng.Source, opts SeedOptions)
+}
+
+type sparseBoard struct {
+	tiles      map[TileKey]*Tile
+	population int
+}
+
+var _ SparseBoard = (*sparseBoard)(nil)
+
+// IsUnbounded reports whether edgeMode asks for an infinite universe, held in
+// a SparseBoard, instead of one of the fixed-size ddd topologies.
+func IsUnbounded(edgeMode string) bool {
+	switch strings.ToUpper(strings.TrimSpace(edgeMode)) {
+	case "INFINITE", "UNBOUNDED":
+		return true
+	}
+	return false
+}
+
+func NewSparseBoard() SparseBoard {
+	return newSparseBoard()
+}
+
+func newSparseBoard() SparseBoard {
+	return &sparseBoard{tiles: make(map[TileKey]*Tile)}
+}
+
+// split returns the tile holding (x, y) and the position inside it.
+func split(x, y int) (TileKey, int, int) {
+	// Arithmetic shifts round toward negative infinity, unlike division.
+	key := TileKey{X: x >> tileShift, Y: y >> tileShift}
+	return key, x & (TileSize - 1), y & (TileSize - 1)
+}
+
+func (b *sparseBoard) Coordinate(x int, y int) bool {
+	key, cx, cy := split(x, y)
+	t, ok := b.tiles[key]
+	if !ok {
+		return false
+	}
+	return t[cy]&(1<<cx) != 0
+}
+
+// SetCoordinate never fails: the board grows to hold any coordinate.
+func (b *sparseBoard) SetCoordinate(x int, y int, value bool) error {
+	key, cx, cy := split(x, y)
+	t, ok := b.tiles[key]
+	if !ok {
+		if !value {
+			return nil
+		}
+		t = &Tile{}
+		b.tiles[key] = t
+	}
+
+	mask := uint64(1) << cx
+	was := t[cy]&mask != 0
+	switch {
+	case value && !was:
+		t[cy] |= mask
+		b.population++
+	case !value && was:
+		t[cy] &^= mask
+		b.population--
+		if t.empty() {
+			delete(b.tiles, key)
+		}
+	}
+	return nil
+}
+
+func (b *sparseBoard) CountSurroundingLive(x int, y int) int {
+	totalAlive := 0
+	for yOffset := -1; yOffset <= 1; yOffset++ {
+		for xOffset := -1; xOffset <= 1; xOffset++ {
+			if (xOffset != 0 || yOffset != 0) && b.Coordinate(x+xOffset, y+yOffset) {
+				totalAlive++
+			}
+		}
+	}
+	return totalAlive
+}
+
+func (b *sparseBoard) Population() int {
+	return b.population
+}
+
+func (b *sparseBoard) Bounds() (minX, minY, maxX, maxY int, ok bool) {
+	for key, t := range b.tiles {
+		for row, bitsRow := range t {
+			if bitsRow == 0 {
+				continue
+			}
+			y := key.Y*TileSize + row
+			lo := key.X*TileSize + bits.TrailingZeros64(bitsRow)
+			hi := key.X*TileSize + 63 - bits.LeadingZeros64(bitsRow)
+			if !ok {
+				minX, minY, maxX, maxY, ok = lo, y, hi, y, true
+				continue
+			}
+			minX, maxX = min(minX, lo), max(maxX, hi)
+			minY, maxY = min(minY, y), max(maxY, y)
+		}
+	}
+	return
+}
+
+func (b *sparseBoard) EachLive(fn func(x, y int)) {
+	for key, t := range b.tiles {
+		for row, bitsRow := range t {
+			for bitsRow != 0 {
+				col := bits.TrailingZeros64(bitsRow)
+				fn(key.X*TileSize+col, key.Y*TileSize+row)
+				bitsRow &= bitsRow - 1
+			}
+		}
+	}
+}
+
+func (b *sparseBoard) Tiles() []TileKey {
+	keys := make([]TileKey, 0, len(b.tiles))
+	for key := range b.tiles {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (b *sparseBoard) Tile(key TileKey) (Tile, bool) {
+	t, ok := b.tiles[key]
+	if !ok {
+		return Tile{}, false
+	}
+	return *t, true
+}
+
+// SetTile replaces a whole tile; an all-dead tile is dropped.
+func (b *sparseBoard) SetTile(key TileKey, tile Tile) {
+	if old, ok := b.tiles[key]; ok {
+		b.population -= old.population()
+		delete(b.tiles, key)
+	}
+	if tile.empty() {
+		return
+	}
+	t := tile
+	b.tiles[key] = &t
+	b.population += t.population()
+}
+
+func (b *sparseBoard) Clear() {
+	clear(b.tiles)
+	b.population = 0
+}
+
+func (b *sparseBoard) SeedRegion(r rng.Source, opts SeedOptions) {
+	Seed(b, r, opts)
+}
+
+func (t *Tile) empty() bool {
+	for _, row := range t {
+		if row != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *Tile) population() int {
+	n := 0
+	for _, row := range t {
+		n += bits.OnesCount64(row)
+	}
+	return n
+}
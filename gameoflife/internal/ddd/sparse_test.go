@@ -0,0 +1,48 @@
+package ddd
+
+import "testing"
+
+func TestSparseBoard_NegativeCoordinatesAndBounds(t *testing.T) {
+	b := newSparseBoard()
+	b.SetCoordinate(-1, -1, true)
+	b.SetCoordinate(-65, 3, true)
+	b.SetCoordinate(200, -130, true)
+
+	if !b.Coordinate(-1, -1) || !b.Coordinate(-65, 3) || !b.Coordinate(200, -130) {
+		t.Fatal("Expected every set cell to read back as alive")
+	}
+	if b.Coordinate(0, 0) || b.Coordinate(63, -1) {
+		t.Error("Expected unset cells to be dead")
+	}
+	if b.Population() != 3 {
+		t.Errorf("Expected population 3, but got %d", b.Population())
+	}
+
+	minX, minY, maxX, maxY, ok := b.Bounds()
+	if !ok || minX != -65 || minY != -130 || maxX != 200 || maxY != 3 {
+		t.Errorf("Expected bounds (-65,-130)-(200,3), but got (%d,%d)-(%d,%d)", minX, minY, maxX, maxY)
+	}
+}
+
+func TestSparseBoard_ClearingCellsDropsTiles(t *testing.T) {
+	b := newSparseBoard()
+	b.SetCoordinate(10, 10, true)
+	b.SetCoordinate(10, 10, false)
+
+	if len(b.Tiles()) != 0 || b.Population() != 0 {
+		t.Errorf("Expected an empty board to hold no tiles, but got %d tiles and population %d", len(b.Tiles()), b.Population())
+	}
+	if _, _, _, _, ok := b.Bounds(); ok {
+		t.Error("Expected no bounds on an empty board")
+	}
+}
+
+func TestSparseBoard_CountSurroundingLiveAcrossTiles(t *testing.T) {
+	b := newSparseBoard()
+	for _, c := range [][2]int{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}} {
+		b.SetCoordinate(c[0], c[1], true)
+	}
+	if got := b.CountSurroundingLive(0, 0); got != 8 {
+		t.Errorf("Expected 8 live neighbors across four tiles, but got %d", got)
+	}
+}
@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+)
+
+// activeTileSize is the width and height of the tiles the active stepper
+// tracks changes in.
+const activeTileSize = 16
+
+// ChangeTracker is implemented by steppers that remember what changed between
+// generations. Edits made to the boards outside Step must be reported, or the
+// stepper may skip the region they are in.
+type ChangeTracker interface {
+	// Touch marks the cell at (x, y) as changed.
+	Touch(x, y int)
+	// Reset forgets all tracking so the next Step evaluates every cell.
+	Reset()
+}
+
+type activeStepper struct {
+	rule ddd.Rule
+	// next[alive][neighbors] is the rule as a lookup table.
+	next [2][9]bool
+	// packed steps pairs of bit-packed boards, which are already cheap to
+	// step in full.
+	packed Stepper
+
+	// The boards of the previous Step; the next Step must either swap them
+	// or pass them again after copying next back into current.
+	current, scratch ddd.GolBoard
+	cols, rows       int
+	tilesX, tilesY   int
+	// active marks the tiles to evaluate in the next Step; upcoming collects
+	// the ones for the Step after.
+	active, upcoming []bool
+}
+
+var (
+	_ Stepper       = (*activeStepper)(nil)
+	_ ChangeTracker = (*activeStepper)(nil)
+)
+
+// NewActiveStepper returns a stepper that only re-evaluates the tiles around
+// cells that changed in the previous generation. A tile none of whose cells or
+// neighbours changed is already correct in next, which holds either the
+// previous generation (when the caller swaps the boards) or a copy of current.
+// Edits made between steps must be reported through ChangeTracker.
+func NewActiveStepper(rule ddd.Rule) Stepper {
+	s := &activeStepper{rule: rule, packed: NewBitStepper(rule, 0)}
+	for n := 0; n <= 8; n++ {
+		s.next[0][n] = rule.Next(false, n)
+		s.next[1][n] = rule.Next(true, n)
+	}
+	return s
+}
+
+func (s *activeStepper) Touch(x, y int) {
+	if s.current == nil {
+		return
+	}
+	s.mark(s.active, x, y)
+}
+
+func (s *activeStepper) Reset() {
+	s.current, s.scratch = nil, nil
+}
+
+func (s *activeStepper) Step(current, next ddd.GolBoard) {
+	_, packed := current.(ddd.BitBoard)
+	_, nextPacked := next.(ddd.BitBoard)
+	if packed && nextPacked {
+		s.packed.Step(current, next)
+		s.Reset()
+		return
+	}
+
+	swapped := current == s.scratch && next == s.current
+	again := current == s.current && next == s.scratch
+	if !swapped && !again || current.Cols() != s.cols || current.Rows() != s.rows {
+		s.resize(current)
+	}
+	s.current, s.scratch = current, next
+
+	clear(s.upcoming)
+	fast := !packed && !nextPacked && (current.Topology() == core.Toroidal || current.Topology() == core.Bounded)
+	for ty := 0; ty < s.tilesY; ty++ {
+		for tx := 0; tx < s.tilesX; tx++ {
+			if s.active[ty*s.tilesX+tx] {
+				s.stepTile(current, next, tx, ty, fast)
+			}
+		}
+	}
+	s.active, s.upcoming = s.upcoming, s.active
+}
+
+// resize starts tracking a new pair of boards with every tile active.
+func (s *activeStepper) resize(board ddd.GolBoard) {
+	s.cols, s.rows = board.Cols(), board.Rows()
+	s.tilesX = (s.cols + activeTileSize - 1) / activeTileSize
+	s.tilesY = (s.rows + activeTileSize - 1) / activeTileSize
+	s.active = make([]bool, s.tilesX*s.tilesY)
+	s.upcoming = make([]bool, s.tilesX*s.tilesY)
+	for i := range s.active {
+		s.active[i] = true
+	}
+}
+
+// stepTile computes one tile and marks the neighbourhood of every cell that
+// changed. fast boards are read from FlatSlice away from the edges.
+func (s *activeStepper) stepTile(current, next ddd.GolBoard, tx, ty int, fast bool) {
+	cols, rows := s.cols, s.rows
+	var cur, out []bool
+	if fast {
+		cur, out = current.FlatSlice(), next.FlatSlice()
+	}
+
+	for y := ty * activeTileSize; y < min(rows, (ty+1)*activeTileSize); y++ {
+		for x := tx * activeTileSize; x < min(cols, (tx+1)*activeTileSize); x++ {
+			var alive, value bool
+			if fast && x > 0 && x < cols-1 && y > 0 && y < rows-1 {
+				i := y*cols + x
+				n := b2i(cur[i-cols-1]) + b2i(cur[i-cols]) + b2i(cur[i-cols+1]) +
+					b2i(cur[i-1]) + b2i(cur[i+1]) +
+					b2i(cur[i+cols-1]) + b2i(cur[i+cols]) + b2i(cur[i+cols+1])
+				alive = cur[i]
+				value = s.next[b2i(alive)][n]
+				out[i] = value
+			} else {
+				alive = current.Coordinate(x, y)
+				value = s.next[b2i(alive)][current.CountSurroundingLive(x, y)]
+				next.SetCoordinate(x, y, value)
+			}
+
+			if value != alive {
+				s.mark(s.upcoming, x, y)
+			}
+		}
+	}
+}
+
+// mark flags the tiles holding (x, y) and its neighbours, following the
+// board's topology across the edges.
+func (s *activeStepper) mark(tiles []bool, x, y int) {
+	topology := s.current.Topology()
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			col, row, ok := topology.Resolve(x+dx, y+dy, s.cols, s.rows)
+			if ok {
+				tiles[(row/activeTileSize)*s.tilesX+col/activeTileSize] = true
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestActiveStepper_MatchesSerial(t *testing.T) {
+	highLife, _ := ddd.ParseRule("B36/S23")
+	topologies := []core.Topology{core.Toroidal, core.Bounded, core.KleinBottle, core.ProjectivePlane}
+	sizes := [][2]int{{1, 1}, {2, 3}, {16, 16}, {37, 23}, {100, 61}}
+
+	for _, rule := range []ddd.Rule{ddd.Conway, highLife} {
+		for _, topology := range topologies {
+			for _, size := range sizes {
+				assertSteppersAgree(t, NewSerialStepper(rule), NewActiveStepper(rule), boolBoard, size[0], size[1], topology)
+			}
+		}
+	}
+}
+
+func TestActiveStepper_SparseBoardsOverManyGenerations(t *testing.T) {
+	// Random soups keep every tile busy; gliders and settling ash crossing
+	// tile seams and board edges are what exercise the skipping.
+	for _, topology := range []core.Topology{core.Toroidal, core.Bounded, core.KleinBottle, core.ProjectivePlane} {
+		want, wantNext := boolBoard(90, 70, topology), boolBoard(90, 70, topology)
+		got, gotNext := boolBoard(90, 70, topology), boolBoard(90, 70, topology)
+		r := rand.New(rand.NewSource(5))
+		for y := 30; y < 42; y++ {
+			for x := 40; x < 52; x++ {
+				alive := r.Intn(2) == 0
+				want.SetCoordinate(x, y, alive)
+				got.SetCoordinate(x, y, alive)
+			}
+		}
+		for _, cell := range [][2]int{{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}} {
+			want.SetCoordinate(cell[0]+5, cell[1]+5, true)
+			got.SetCoordinate(cell[0]+5, cell[1]+5, true)
+		}
+
+		serial, active := NewSerialStepper(ddd.Conway), NewActiveStepper(ddd.Conway)
+		for gen := 1; gen <= 300; gen++ {
+			serial.Step(want, wantNext)
+			active.Step(got, gotNext)
+			want, wantNext = wantNext, want
+			got, gotNext = gotNext, got
+			for i := range want.FlatSlice() {
+				if want.FlatSlice()[i] != got.FlatSlice()[i] {
+					t.Fatalf("%v generation %d: cell (%d, %d) differs", topology, gen, i%90, i/90)
+				}
+			}
+		}
+	}
+}
+
+func TestActiveStepper_CopyingBoardEngine(t *testing.T) {
+	want := NewBoardEngine(randomBoard(boolBoard, 64, 64, core.Toroidal, 3), boolBoard(64, 64, core.Toroidal), NewSerialStepper(ddd.Conway))
+	board := randomBoard(boolBoard, 64, 64, core.Toroidal, 3)
+	got := NewBoardEngine(board, boolBoard(64, 64, core.Toroidal), NewActiveStepper(ddd.Conway))
+
+	for gen := 1; gen <= 40; gen++ {
+		want.Step(1)
+		got.Step(1)
+		for y := int64(0); y < 64; y++ {
+			for x := int64(0); x < 64; x++ {
+				if want.Alive(x, y) != got.Alive(x, y) {
+					t.Fatalf("generation %d: cell (%d, %d) differs", gen, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestActiveStepper_TouchPicksUpEdits(t *testing.T) {
+	stepper := NewActiveStepper(ddd.Conway)
+	current, next := boolBoard(48, 48, core.Bounded), boolBoard(48, 48, core.Bounded)
+
+	// Let the empty board settle so every tile goes inactive.
+	for i := 0; i < 2; i++ {
+		stepper.Step(current, next)
+		current, next = next, current
+	}
+
+	// Drop a blinker far from anything that changed, in both buffers.
+	for x := 30; x <= 32; x++ {
+		current.SetCoordinate(x, 40, true)
+		next.SetCoordinate(x, 40, true)
+		stepper.(ChangeTracker).Touch(x, 40)
+	}
+	stepper.Step(current, next)
+	if !next.Coordinate(31, 39) || !next.Coordinate(31, 41) || next.Coordinate(30, 40) {
+		t.Error("Expected the touched blinker to turn vertical")
+	}
+}
+
+// sparseSoup scatters a few random 32x32 patches over an otherwise empty board.
+func sparseSoup(newBoard boardMaker, size int) ddd.GolBoard {
+	b := newBoard(size, size, core.Toroidal)
+	r := rand.New(rand.NewSource(1))
+	for patch := 0; patch < 8; patch++ {
+		px, py := r.Intn(size), r.Intn(size)
+		for y := 0; y < 32; y++ {
+			for x := 0; x < 32; x++ {
+				b.SetCoordinate(px+x, py+y, r.Intn(2) == 0)
+			}
+		}
+	}
+	return b
+}
+
+// BenchmarkSparseSoup compares the steppers on a mostly empty board, where the
+// active stepper only visits the tiles around the patches:
+// go test -bench SparseSoup ./gameoflife/internal/engine
+func BenchmarkSparseSoup(b *testing.B) {
+	steppers := map[string]func() Stepper{
+		"serial":   func() Stepper { return NewSerialStepper(ddd.Conway) },
+		"parallel": func() Stepper { return NewParallelStepper(ddd.Conway, 0) },
+		"active":   func() Stepper { return NewActiveStepper(ddd.Conway) },
+	}
+	for _, name := range []string{"serial", "parallel", "active"} {
+		for _, size := range []int{1024, 4096} {
+			b.Run(fmt.Sprintf("%s/%d", name, size), func(b *testing.B) {
+				stepper := steppers[name]()
+				current, next := sparseSoup(boolBoard, size), boolBoard(size, size, core.Toroidal)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					stepper.Step(current, next)
+					current, next = next, current
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"runtime"
+	"sync"
+)
+
+type bitStepper struct {
+	rule    ddd.Rule
+	workers int
+	lanes   laneRule
+	// fallback steps boards that are not bit-packed.
+	fallback Stepper
+}
+
+var _ Stepper = (*bitStepper)(nil)
+
+// NewBitStepper returns a stepper for ddd.BitBoard boards that works on 64
+// cells at a time: the eight neighbour words are summed with bitwise adders
+// and the rule is applied to the resulting 4-bit counts. Rows are split into
+// bands across up to workers goroutines; workers <= 0 uses one per CPU.
+// Boards that are not bit-packed are stepped serially.
+func NewBitStepper(rule ddd.Rule, workers int) Stepper {
+	return newBitStepper(rule, workers, NewSerialStepper(rule))
+}
+
+func newBitStepper(rule ddd.Rule, workers int, fallback Stepper) *bitStepper {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &bitStepper{rule: rule, workers: workers, lanes: newLaneRule(rule), fallback: fallback}
+}
+
+func (s *bitStepper) Step(current, next ddd.GolBoard) {
+	cur, ok := current.(ddd.BitBoard)
+	out, nextOK := next.(ddd.BitBoard)
+	if !ok || !nextOK {
+		s.fallback.Step(current, next)
+		return
+	}
+
+	rows := cur.Rows()
+	bands := min(s.workers, max(1, rows/minBandRows))
+
+	var wg sync.WaitGroup
+	for i := 0; i < bands; i++ {
+		y0, y1 := rows*i/bands, rows*(i+1)/bands
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.stepBand(cur, out, y0, y1)
+		}()
+	}
+	wg.Wait()
+}
+
+// stepBand computes rows [y0, y1). The word pass treats everything past the
+// edges as dead, which is exactly a Bounded board; on the other topologies the
+// cells along the edges are then redone through Coordinate.
+func (s *bitStepper) stepBand(current, next ddd.BitBoard, y0, y1 int) {
+	cols, rows, stride := current.Cols(), current.Rows(), current.Stride()
+	cur, out := current.Words(), next.Words()
+	last := ddd.LastWordMask(cols)
+
+	row := func(y int) []uint64 {
+		if y < 0 || y >= rows {
+			return nil
+		}
+		return cur[y*stride : (y+1)*stride]
+	}
+
+	for y := y0; y < y1; y++ {
+		above, here, below := row(y-1), row(y), row(y+1)
+		for i := 0; i < stride; i++ {
+			aW, a, aE := shifted(above, i)
+			hW, h, hE := shifted(here, i)
+			bW, b, bE := shifted(below, i)
+			c0, c1, c2, c3 := add8(aW, a, aE, hW, hE, bW, b, bE)
+
+			w := s.lanes.next(h, c0, c1, c2, c3)
+			if i == stride-1 {
+				w &= last
+			}
+			out[y*stride+i] = w
+		}
+	}
+
+	if current.Topology() == core.Bounded {
+		return
+	}
+	fix := func(x, y int) {
+		next.SetCoordinate(x, y, s.rule.Next(current.Coordinate(x, y), current.CountSurroundingLive(x, y)))
+	}
+	for y := y0; y < y1; y++ {
+		if y == 0 || y == rows-1 {
+			for x := 0; x < cols; x++ {
+				fix(x, y)
+			}
+			continue
+		}
+		fix(0, y)
+		fix(cols-1, y)
+	}
+}
+
+// shifted returns word i of r together with the words holding each cell's
+// west and east neighbours. A nil row is all dead.
+func shifted(r []uint64, i int) (west, word, east uint64) {
+	if r == nil {
+		return 0, 0, 0
+	}
+	word = r[i]
+	west, east = word<<1, word>>1
+	if i > 0 {
+		west |= r[i-1] >> 63
+	}
+	if i+1 < len(r) {
+		east |= r[i+1] << 63
+	}
+	return west, word, east
+}
+
+// add8 sums eight one-bit inputs lane by lane into a 4-bit count c3c2c1c0.
+func add8(a, b, c, d, e, f, g, h uint64) (c0, c1, c2, c3 uint64) {
+	s1, k1 := fullAdd(a, b, c)
+	s2, k2 := fullAdd(d, e, f)
+	s3, k3 := g^h, g&h
+	c0, k4 := fullAdd(s1, s2, s3)
+
+	// k1..k4 each carry a weight of two.
+	t1, m1 := fullAdd(k1, k2, k3)
+	c1, m2 := t1^k4, t1&k4
+	return c0, c1, m1 ^ m2, m1 & m2
+}
+
+func fullAdd(a, b, c uint64) (sum, carry uint64) {
+	return a ^ b ^ c, a&b | c&(a^b)
+}
+
+// laneRule applies a rule to 64 cells at once.
+type laneRule struct {
+	// birth and survive list the neighbour counts the rule acts on.
+	birth   []int
+	survive []int
+}
+
+func newLaneRule(rule ddd.Rule) laneRule {
+	var r laneRule
+	for n := 0; n <= 8; n++ {
+		if rule.Born(n) {
+			r.birth = append(r.birth, n)
+		}
+		if rule.Survives(n) {
+			r.survive = append(r.survive, n)
+		}
+	}
+	return r
+}
+
+// next returns the lanes alive in the next generation, given the lanes alive
+// now and their neighbour counts.
+func (r laneRule) next(alive, c0, c1, c2, c3 uint64) uint64 {
+	return alive&matching(r.survive, c0, c1, c2, c3) | ^alive&matching(r.birth, c0, c1, c2, c3)
+}
+
+// matching returns the lanes whose count is one of counts.
+func matching(counts []int, c0, c1, c2, c3 uint64) uint64 {
+	var m uint64
+	for _, n := range counts {
+		eq := ^uint64(0)
+		for bit, c := range [4]uint64{c0, c1, c2, c3} {
+			if n&(1<<bit) != 0 {
+				eq &= c
+			} else {
+				eq &^= c
+			}
+		}
+		m |= eq
+	}
+	return m
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"testing"
+)
+
+func TestBitStepper_MatchesSerial(t *testing.T) {
+	highLife, _ := ddd.ParseRule("B36/S23")
+	// B0 rules turn every dead cell with no neighbours on, including the
+	// padding past the last column, which must stay clear.
+	flash, _ := ddd.ParseRule("B0123/S45")
+	topologies := []core.Topology{core.Toroidal, core.Bounded, core.KleinBottle, core.ProjectivePlane}
+	sizes := [][2]int{{1, 1}, {2, 3}, {63, 5}, {64, 4}, {65, 7}, {130, 97}}
+
+	for _, rule := range []ddd.Rule{ddd.Conway, highLife, flash} {
+		for _, topology := range topologies {
+			for _, size := range sizes {
+				for _, workers := range []int{1, 3} {
+					assertSteppersAgree(t, NewSerialStepper(rule), NewBitStepper(rule, workers), bitBoard, size[0], size[1], topology)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelStepper_HandlesBitBoards(t *testing.T) {
+	for _, topology := range []core.Topology{core.Toroidal, core.KleinBottle} {
+		assertSteppersAgree(t, NewSerialStepper(ddd.Conway), NewParallelStepper(ddd.Conway, 2), bitBoard, 70, 40, topology)
+	}
+}
+
+func TestBitStepper_SteppingBoolBoards(t *testing.T) {
+	assertSteppersAgree(t, NewSerialStepper(ddd.Conway), NewBitStepper(ddd.Conway, 0), boolBoard, 20, 20, core.Toroidal)
+}
+
+func TestBoardEngine_BitBoard(t *testing.T) {
+	board := ddd.NewBitBoard(5, 5, core.Bounded)
+	board.SetCoordinate(1, 2, true)
+	board.SetCoordinate(2, 2, true)
+	board.SetCoordinate(3, 2, true)
+	e := NewBoardEngine(board, ddd.NewBitBoard(5, 5, core.Bounded), NewBitStepper(ddd.Conway, 0))
+
+	e.Step(1)
+	if !board.Coordinate(2, 1) || !board.Coordinate(2, 3) || board.Coordinate(1, 2) {
+		t.Error("Expected the blinker to turn vertical")
+	}
+	if got := e.Population(); got != 3 {
+		t.Errorf("Expected a population of 3, but got %d", got)
+	}
+}
+
+// BenchmarkBitStepper10k steps the 10000 x 10000 board the bit-packed
+// stepper was built for: go test -bench BitStepper10k ./gameoflife/internal/engine
+func BenchmarkBitStepper10k(b *testing.B) {
+	benchmarkStepper(b, NewBitStepper(ddd.Conway, 0), bitBoard, 10000)
+}
@@ -0,0 +1,328 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// Cell is the position of a live cell.
+type Cell struct {
+	X, Y int
+}
+
+// Behaviour is what a board or object settled into.
+type Behaviour uint8
+
+const (
+	// Evolving means no repeat has been seen yet.
+	Evolving Behaviour = iota
+	// Extinct means nothing is alive.
+	Extinct
+	// StillLife repeats every generation in place.
+	StillLife
+	// Oscillator repeats in place every Period generations.
+	Oscillator
+	// Spaceship repeats every Period generations moved by (DX, DY).
+	Spaceship
+)
+
+var behaviourNames = map[Behaviour]string{
+	Evolving:   "evolving",
+	Extinct:    "extinct",
+	StillLife:  "still life",
+	Oscillator: "oscillator",
+	Spaceship:  "spaceship",
+}
+
+func (b Behaviour) String() string {
+	if name, ok := behaviourNames[b]; ok {
+		return name
+	}
+	return fmt.Sprintf("Behaviour(%d)", uint8(b))
+}
+
+// Verdict is the outcome of cycle detection.
+type Verdict struct {
+	Behaviour Behaviour
+	// Period is the number of generations per cycle, 1 for a still life and
+	// 0 while evolving or extinct.
+	Period int
+	// DX and DY are how far a spaceship moves each period.
+	DX, DY int
+	// Start is the first generation seen in the cycle.
+	Start int
+}
+
+// Settled reports whether the verdict is final: extinct or periodic.
+func (v Verdict) Settled() bool {
+	return v.Behaviour != Evolving
+}
+
+func (v Verdict) String() string {
+	switch v.Behaviour {
+	case Oscillator:
+		return fmt.Sprintf("oscillator of period %d", v.Period)
+	case Spaceship:
+		return fmt.Sprintf("spaceship with displacement (%d, %d) every %d generations", v.DX, v.DY, v.Period)
+	}
+	return v.Behaviour.String()
+}
+
+// CycleDetector watches successive generations and reports when one repeats
+// an earlier one, up to translation. Generations are compared by a hash of
+// their live cells, so in theory two different generations can collide; the
+// population and bounding box must match too, which makes that vanishingly
+// unlikely.
+type CycleDetector interface {
+	// Observe records the live cells of generation and returns the verdict
+	// so far. Generations must be observed in order.
+	Observe(generation int, cells []Cell) Verdict
+	Verdict() Verdict
+	// Reset forgets every generation seen, for example after an edit.
+	Reset()
+}
+
+// snapshot is what the detector remembers of one generation.
+type snapshot struct {
+	generation    int
+	shape         uint64
+	population    int
+	minX, minY    int
+	width, height int
+}
+
+type cycleDetector struct {
+	// history is a ring of the last len(history) generations; next is the
+	// slot the next one goes in.
+	history []snapshot
+	next    int
+	count   int
+	verdict Verdict
+}
+
+var _ CycleDetector = (*cycleDetector)(nil)
+
+// DefaultHistory is enough generations to catch every pattern in the library,
+// up to the period 30 glider gun, with room to spare.
+const DefaultHistory = 128
+
+// NewCycleDetector returns a detector that remembers the last history
+// generations, so it finds cycles of period up to history.
+func NewCycleDetector(history int) CycleDetector {
+	return &cycleDetector{history: make([]snapshot, max(1, history))}
+}
+
+func (d *cycleDetector) Observe(generation int, cells []Cell) Verdict {
+	s := takeSnapshot(generation, cells)
+	if s.population == 0 {
+		d.verdict = Verdict{Behaviour: Extinct, Start: generation}
+		d.record(s)
+		return d.verdict
+	}
+
+	// Newest first, so the shortest period wins.
+	d.verdict = Verdict{}
+	for i := 1; i <= d.count; i++ {
+		old := d.history[(d.next-i+len(d.history))%len(d.history)]
+		if old.shape != s.shape || old.population != s.population || old.width != s.width || old.height != s.height {
+			continue
+		}
+		v := Verdict{Period: generation - old.generation, DX: s.minX - old.minX, DY: s.minY - old.minY, Start: old.generation}
+		switch {
+		case v.DX != 0 || v.DY != 0:
+			v.Behaviour = Spaceship
+		case v.Period == 1:
+			v.Behaviour = StillLife
+		default:
+			v.Behaviour = Oscillator
+		}
+		d.verdict = v
+		break
+	}
+	d.record(s)
+	return d.verdict
+}
+
+func (d *cycleDetector) record(s snapshot) {
+	d.history[d.next] = s
+	d.next = (d.next + 1) % len(d.history)
+	d.count = min(d.count+1, len(d.history))
+}
+
+func (d *cycleDetector) Verdict() Verdict {
+	return d.verdict
+}
+
+func (d *cycleDetector) Reset() {
+	d.next, d.count = 0, 0
+	d.verdict = Verdict{}
+}
+
+// takeSnapshot hashes cells relative to their bounding box, so a pattern
+// hashes the same wherever it is. The hash is a sum, so cell order does not
+// matter.
+func takeSnapshot(generation int, cells []Cell) snapshot {
+	s := snapshot{generation: generation, population: len(cells)}
+	if len(cells) == 0 {
+		return s
+	}
+	minX, minY, maxX, maxY := cells[0].X, cells[0].Y, cells[0].X, cells[0].Y
+	for _, c := range cells[1:] {
+		minX, maxX = min(minX, c.X), max(maxX, c.X)
+		minY, maxY = min(minY, c.Y), max(maxY, c.Y)
+	}
+	for _, c := range cells {
+		s.shape += mix(uint64(uint32(c.X-minX))<<32 | uint64(uint32(c.Y-minY)))
+	}
+	s.minX, s.minY, s.width, s.height = minX, minY, maxX-minX+1, maxY-minY+1
+	return s
+}
+
+// mix is the SplitMix64 finalizer, which spreads neighbouring cells far
+// apart in the hash.
+func mix(z uint64) uint64 {
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// LiveCells lists the live cells of a fixed-size board in reading order. A
+// bit board is read word by word rather than unpacked.
+func LiveCells(b ddd.GolBoard) []Cell {
+	var cells []Cell
+	if bb, ok := b.(ddd.BitBoard); ok {
+		words, stride := bb.Words(), bb.Stride()
+		for i, word := range words {
+			for word != 0 {
+				x := i%stride*64 + bits.TrailingZeros64(word)
+				cells = append(cells, Cell{X: x, Y: i / stride})
+				word &= word - 1
+			}
+		}
+		return cells
+	}
+	for i, alive := range b.FlatSlice() {
+		if alive {
+			cells = append(cells, Cell{X: i % b.Cols(), Y: i / b.Cols()})
+		}
+	}
+	return cells
+}
+
+// SparseLiveCells lists the live cells of an unbounded board.
+func SparseLiveCells(b ddd.SparseBoard) []Cell {
+	cells := make([]Cell, 0, b.Population())
+	b.EachLive(func(x, y int) { cells = append(cells, Cell{X: x, Y: y}) })
+	return cells
+}
+
+// EngineLiveCells lists the live cells of e within its bounding box.
+func EngineLiveCells(e Engine) []Cell {
+	minX, minY, maxX, maxY, ok := e.Bounds()
+	if !ok {
+		return nil
+	}
+	var cells []Cell
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			if e.Alive(x, y) {
+				cells = append(cells, Cell{X: int(x), Y: int(y)})
+			}
+		}
+	}
+	return cells
+}
+
+// RunUntilSettled steps e until its generations start repeating or it dies
+// out, giving up after maxGenerations. It remembers history generations, so
+// only cycles of period up to history are found.
+func RunUntilSettled(e Engine, maxGenerations int, history int) Verdict {
+	d := NewCycleDetector(history)
+	v := d.Observe(int(e.Generation()), EngineLiveCells(e))
+	for range maxGenerations {
+		if v.Settled() {
+			break
+		}
+		e.Step(1)
+		v = d.Observe(int(e.Generation()), EngineLiveCells(e))
+	}
+	return v
+}
+
+// objectGap is how far apart, in cells, two live cells may be and still
+// belong to the same object. Two keeps the separate pieces of a pulsar or a
+// pentadecathlon together while parting objects that cannot touch within a
+// generation.
+const objectGap = 2
+
+// SplitObjects groups live cells into objects: sets of cells each within
+// objectGap (in both directions) of another cell of the same set. Objects
+// are returned in the reading order of their top-left cell, each sorted in
+// reading order.
+func SplitObjects(cells []Cell) [][]Cell {
+	remaining := make(map[Cell]bool, len(cells))
+	for _, c := range cells {
+		remaining[c] = true
+	}
+	sorted := slices.Clone(cells)
+	slices.SortFunc(sorted, compareCells)
+
+	var objects [][]Cell
+	for _, start := range sorted {
+		if !remaining[start] {
+			continue
+		}
+		delete(remaining, start)
+		object := []Cell{start}
+		for i := 0; i < len(object); i++ {
+			c := object[i]
+			for dy := -objectGap; dy <= objectGap; dy++ {
+				for dx := -objectGap; dx <= objectGap; dx++ {
+					n := Cell{X: c.X + dx, Y: c.Y + dy}
+					if remaining[n] {
+						delete(remaining, n)
+						object = append(object, n)
+					}
+				}
+			}
+		}
+		slices.SortFunc(object, compareCells)
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+func compareCells(a, b Cell) int {
+	if a.Y != b.Y {
+		return a.Y - b.Y
+	}
+	return a.X - b.X
+}
+
+// ClassifyObject runs object on its own, on an empty unbounded board, for up
+// to maxPeriod generations and reports what it does. An object that is only
+// part of a cycle because of its neighbours, or that needs more than
+// maxPeriod generations to repeat, comes back Evolving.
+func ClassifyObject(rule ddd.Rule, object []Cell, maxPeriod int) Verdict {
+	current, next := ddd.NewSparseBoard(), ddd.NewSparseBoard()
+	for _, c := range object {
+		current.SetCoordinate(c.X, c.Y, true)
+	}
+	stepper := NewSparseStepper(rule)
+	d := NewCycleDetector(maxPeriod)
+	v := d.Observe(0, object)
+	for gen := 1; gen <= maxPeriod && !v.Settled(); gen++ {
+		stepper.Step(current, next)
+		current, next = next, current
+		v = d.Observe(gen, SparseLiveCells(current))
+	}
+	if v.Settled() && v.Start != 0 {
+		// The object only fell into this cycle after a while, so on the
+		// board it was still evolving.
+		return Verdict{}
+	}
+	return v
+}
@@ -0,0 +1,168 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// cellsOf reads an RLE body and returns its live cells moved by (x, y).
+func cellsOf(t *testing.T, rle string, x, y int) []Cell {
+	t.Helper()
+	p, err := ddd.ReadRLE(strings.NewReader(rle))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	var cells []Cell
+	for py := 0; py < p.Height; py++ {
+		for px := 0; px < p.Width; px++ {
+			if p.Alive(px, py) {
+				cells = append(cells, Cell{X: x + px, Y: y + py})
+			}
+		}
+	}
+	return cells
+}
+
+// sparseEngine returns an unbounded engine holding cells.
+func sparseEngine(cells []Cell) Engine {
+	b := ddd.NewSparseBoard()
+	for _, c := range cells {
+		b.SetCoordinate(c.X, c.Y, true)
+	}
+	return NewSparseEngine(b, ddd.Conway)
+}
+
+const (
+	blockRLE   = "x = 2, y = 2\n2o$2o!"
+	blinkerRLE = "x = 3, y = 1\n3o!"
+	gliderRLE  = "x = 3, y = 3\nbo$2bo$3o!"
+	pulsarRLE  = "x = 13, y = 13\n2b3o3b3o2b2$o4bobo4bo$o4bobo4bo$o4bobo4bo$2b3o3b3o2b2$2b3o3b3o2b$o4bobo4bo$o4bobo4bo$o4bobo4bo2$2b3o3b3o!"
+	diehardRLE = "x = 8, y = 3\n6bob$2o6b$bo3b3o!"
+)
+
+func TestRunUntilSettled(t *testing.T) {
+	tests := []struct {
+		name string
+		rle  string
+		want Verdict
+	}{
+		{"block", blockRLE, Verdict{Behaviour: StillLife, Period: 1}},
+		{"blinker", blinkerRLE, Verdict{Behaviour: Oscillator, Period: 2}},
+		{"pulsar", pulsarRLE, Verdict{Behaviour: Oscillator, Period: 3}},
+		{"glider", gliderRLE, Verdict{Behaviour: Spaceship, Period: 4, DX: 1, DY: 1}},
+		{"diehard", diehardRLE, Verdict{Behaviour: Extinct, Start: 130}},
+	}
+	for _, tt := range tests {
+		got := RunUntilSettled(sparseEngine(cellsOf(t, tt.rle, 5, -3)), 200, DefaultHistory)
+		if got != tt.want {
+			t.Errorf("Expected %s to be %v (%+v), but got %v (%+v)", tt.name, tt.want, tt.want, got, got)
+		}
+	}
+}
+
+func TestRunUntilSettled_GivesUp(t *testing.T) {
+	got := RunUntilSettled(sparseEngine(cellsOf(t, diehardRLE, 0, 0)), 50, DefaultHistory)
+	if got.Settled() {
+		t.Errorf("Expected Diehard to still be evolving after 50 generations, but got %v", got)
+	}
+}
+
+func TestCycleDetector_ShortHistoryMissesLongPeriods(t *testing.T) {
+	got := RunUntilSettled(sparseEngine(cellsOf(t, pulsarRLE, 0, 0)), 20, 2)
+	if got.Settled() {
+		t.Errorf("Expected a 2 generation history to miss period 3, but got %v", got)
+	}
+}
+
+func TestCycleDetector_Reset(t *testing.T) {
+	d := NewCycleDetector(DefaultHistory)
+	block := cellsOf(t, blockRLE, 0, 0)
+	d.Observe(0, block)
+	if v := d.Observe(1, block); v.Behaviour != StillLife {
+		t.Fatalf("Expected a still life, but got %v", v)
+	}
+	d.Reset()
+	if d.Verdict().Settled() {
+		t.Error("Expected no verdict after a reset")
+	}
+	if v := d.Observe(2, block); v.Settled() {
+		t.Errorf("Expected the history to be forgotten, but got %v", v)
+	}
+}
+
+func TestCycleDetector_FixedBoard(t *testing.T) {
+	board := ddd.NewGOLBoard(20, 20, core.Toroidal)
+	for _, c := range cellsOf(t, blinkerRLE, 8, 8) {
+		board.SetCoordinate(c.X, c.Y, true)
+	}
+	e := NewBoardEngine(board, ddd.NewGOLBoard(20, 20, core.Toroidal), NewSerialStepper(ddd.Conway))
+	d := NewCycleDetector(DefaultHistory)
+	var v Verdict
+	for gen := 0; gen < 3; gen++ {
+		v = d.Observe(gen, LiveCells(board))
+		e.Step(1)
+	}
+	if v.Behaviour != Oscillator || v.Period != 2 || v.Start != 0 {
+		t.Errorf("Expected an oscillator of period 2 from generation 0, but got %+v", v)
+	}
+}
+
+func TestLiveCells_BitBoardMatchesBoolBoard(t *testing.T) {
+	boolBoard := ddd.NewGOLBoard(130, 9, core.Toroidal)
+	bitBoard := ddd.NewBitBoard(130, 9, core.Toroidal)
+	for _, c := range []Cell{{0, 0}, {63, 0}, {64, 0}, {129, 0}, {5, 4}, {128, 8}} {
+		boolBoard.SetCoordinate(c.X, c.Y, true)
+		bitBoard.SetCoordinate(c.X, c.Y, true)
+	}
+	want, got := LiveCells(boolBoard), LiveCells(bitBoard)
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected %v, but got %v", want, got)
+	}
+}
+
+func TestVerdict_String(t *testing.T) {
+	v := Verdict{Behaviour: Spaceship, Period: 4, DX: 1, DY: -1}
+	if got := v.String(); got != "spaceship with displacement (1, -1) every 4 generations" {
+		t.Errorf("Expected the spaceship description, but got %q", got)
+	}
+	if got := (Verdict{Behaviour: Oscillator, Period: 15}).String(); got != "oscillator of period 15" {
+		t.Errorf("Expected the oscillator description, but got %q", got)
+	}
+}
+
+func TestSplitObjects(t *testing.T) {
+	var cells []Cell
+	cells = append(cells, cellsOf(t, gliderRLE, 20, 20)...)
+	cells = append(cells, cellsOf(t, blockRLE, 0, 0)...)
+	cells = append(cells, cellsOf(t, pulsarRLE, 0, 5)...)
+
+	objects := SplitObjects(cells)
+	if len(objects) != 3 {
+		t.Fatalf("Expected a block, a pulsar and a glider, but got %d objects", len(objects))
+	}
+	for i, want := range []int{4, 48, 5} {
+		if len(objects[i]) != want {
+			t.Errorf("Expected object %d to have %d cells, but got %d", i, want, len(objects[i]))
+		}
+	}
+
+	want := []Verdict{
+		{Behaviour: StillLife, Period: 1},
+		{Behaviour: Oscillator, Period: 3},
+		{Behaviour: Spaceship, Period: 4, DX: 1, DY: 1},
+	}
+	for i, object := range objects {
+		if got := ClassifyObject(ddd.Conway, object, DefaultHistory); got != want[i] {
+			t.Errorf("Expected object %d to be %v, but got %v", i, want[i], got)
+		}
+	}
+}
+
+func TestClassifyObject_NotYetSettled(t *testing.T) {
+	if got := ClassifyObject(ddd.Conway, cellsOf(t, diehardRLE, 0, 0), DefaultHistory); got.Settled() {
+		t.Errorf("Expected Diehard to be evolving, but got %v", got)
+	}
+}
@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Engine advances a Game of Life universe and reports on it. Coordinates are
+// int64 so unbounded engines such as HashLife can address huge universes.
+type Engine interface {
+	// Step advances the universe by the given number of generations.
+	Step(generations int64)
+	Generation() int64
+	Population() int64
+	// Bounds returns the bounding box of the live cells, inclusive. ok is
+	// false when nothing is alive.
+	Bounds() (minX, minY, maxX, maxY int64, ok bool)
+	Alive(x, y int64) bool
+}
+
+// ErrBirthOnZero is returned for B0 rules on an unbounded universe: they
+// switch on the whole infinite background, which neither the sparse stepper
+// nor HashLife can represent.
+var ErrBirthOnZero = errors.New("rules with B0 cannot run on an unbounded board")
+
+// Stepper computes one generation of a fixed-size board, reading current and
+// writing every cell of next. current and next must have the same size.
+type Stepper interface {
+	Step(current, next ddd.GolBoard)
+}
+
+// NewStepper returns the stepper named by kind ("SERIAL", "PARALLEL",
+// "BITWISE" or "ACTIVE", case-insensitive; empty means PARALLEL). workers only
+// applies to PARALLEL and BITWISE.
+func NewStepper(kind string, rule ddd.Rule, workers int) (Stepper, error) {
+	switch strings.ToUpper(strings.TrimSpace(kind)) {
+	case "SERIAL":
+		return NewSerialStepper(rule), nil
+	case "", "PARALLEL":
+		return NewParallelStepper(rule, workers), nil
+	case "BITWISE":
+		return NewBitStepper(rule, workers), nil
+	case "ACTIVE":
+		return NewActiveStepper(rule), nil
+	}
+	return nil, fmt.Errorf("unknown stepper %q; expected SERIAL, PARALLEL, BITWISE or ACTIVE", kind)
+}
+
+type serialStepper struct {
+	rule ddd.Rule
+}
+
+var _ Stepper = (*serialStepper)(nil)
+
+// NewSerialStepper returns the straightforward single-threaded stepper that
+// visits every cell once per generation.
+func NewSerialStepper(rule ddd.Rule) Stepper {
+	return &serialStepper{rule: rule}
+}
+
+func (s *serialStepper) Step(current, next ddd.GolBoard) {
+	for y := 0; y < current.Rows(); y++ {
+		for x := 0; x < current.Cols(); x++ {
+			alive := current.Coordinate(x, y)
+			neighbors := current.CountSurroundingLive(x, y)
+			next.SetCoordinate(x, y, s.rule.Next(alive, neighbors))
+		}
+	}
+}
+
+// boardEngine runs a Stepper over a fixed-size board, swapping buffers each generation.
+type boardEngine struct {
+	current    ddd.GolBoard
+	next       ddd.GolBoard
+	stepper    Stepper
+	generation int64
+}
+
+var _ Engine = (*boardEngine)(nil)
+
+// NewBoardEngine wraps board in an Engine that advances it with stepper.
+// The board is updated in place; scratch is a same-sized board used as the
+// write buffer.
+func NewBoardEngine(board, scratch ddd.GolBoard, stepper Stepper) Engine {
+	return &boardEngine{current: board, next: scratch, stepper: stepper}
+}
+
+func (e *boardEngine) Step(generations int64) {
+	for i := int64(0); i < generations; i++ {
+		e.stepper.Step(e.current, e.next)
+		if current, ok := e.current.(ddd.BitBoard); ok {
+			if next, ok := e.next.(ddd.BitBoard); ok {
+				copy(current.Words(), next.Words())
+				continue
+			}
+		}
+		e.current.CopyBoard(e.next.FlatSlice())
+	}
+	e.generation += generations
+}
+
+func (e *boardEngine) Generation() int64 {
+	return e.generation
+}
+
+func (e *boardEngine) Population() int64 {
+	if b, ok := e.current.(ddd.BitBoard); ok {
+		return int64(b.Population())
+	}
+	var n int64
+	for _, alive := range e.current.FlatSlice() {
+		if alive {
+			n++
+		}
+	}
+	return n
+}
+
+func (e *boardEngine) Bounds() (minX, minY, maxX, maxY int64, ok bool) {
+	cols := e.current.Cols()
+	for i, alive := range e.current.FlatSlice() {
+		if !alive {
+			continue
+		}
+		x, y := int64(i%cols), int64(i/cols)
+		if !ok {
+			minX, minY, maxX, maxY, ok = x, y, x, y, true
+			continue
+		}
+		minX, maxX = min(minX, x), max(maxX, x)
+		minY, maxY = min(minY, y), max(maxY, y)
+	}
+	return
+}
+
+func (e *boardEngine) Alive(x, y int64) bool {
+	if !e.current.InBounds(int(x), int(y)) {
+		return false
+	}
+	return e.current.Coordinate(int(x), int(y))
+}
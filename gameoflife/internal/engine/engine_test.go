@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"testing"
+)
+
+func TestBoardEngine_BlinkerOscillates(t *testing.T) {
+	board := ddd.NewGOLBoard(5, 5, core.Bounded)
+	scratch := ddd.NewGOLBoard(5, 5, core.Bounded)
+	for x := 1; x <= 3; x++ {
+		board.SetCoordinate(x, 2, true)
+	}
+	e := NewBoardEngine(board, scratch, NewSerialStepper(ddd.Conway))
+
+	e.Step(1)
+	minX, minY, maxX, maxY, ok := e.Bounds()
+	if !ok || minX != 2 || maxX != 2 || minY != 1 || maxY != 3 {
+		t.Errorf("Expected a vertical blinker at x=2, y=1..3, but got (%d,%d)-(%d,%d)", minX, minY, maxX, maxY)
+	}
+
+	e.Step(1)
+	if !board.Coordinate(1, 2) || !board.Coordinate(3, 2) || board.Coordinate(2, 1) {
+		t.Error("Expected the blinker to be horizontal again after two generations")
+	}
+	if e.Population() != 3 || e.Generation() != 2 {
+		t.Errorf("Expected population 3 at generation 2, but got %d at %d", e.Population(), e.Generation())
+	}
+}
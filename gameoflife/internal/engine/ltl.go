@@ -0,0 +1,34 @@
+package engine
+
+import "SideProjectGames/gameoflife/internal/ddd"
+
+type ltlStepper struct {
+	rule ddd.LtLRule
+	// counts holds the neighbourhood count of every cell and sat the
+	// summed-area table, both reused between generations.
+	counts []int
+	sat    []int32
+}
+
+var _ Stepper = (*ltlStepper)(nil)
+
+// NewLtLStepper returns a stepper for a Larger-than-Life rule. It counts the
+// whole board at once with ddd.CountAll, so its cost per cell barely grows
+// with the radius. The board is two-state: a rule with dying states is run
+// as if a live cell that does not survive dies at once.
+func NewLtLStepper(rule ddd.LtLRule) Stepper {
+	return &ltlStepper{rule: rule}
+}
+
+func (s *ltlStepper) Step(current, next ddd.GolBoard) {
+	cols, rows := current.Cols(), current.Rows()
+	if len(s.counts) != cols*rows {
+		s.counts = make([]int, cols*rows)
+	}
+	s.sat = ddd.CountAll(s.rule.Neighborhood(), cols, rows, current.Coordinate, s.counts, s.sat)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			next.SetCoordinate(x, y, s.rule.Next(current.Coordinate(x, y), s.counts[y*cols+x]))
+		}
+	}
+}
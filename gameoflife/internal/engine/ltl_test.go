@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"slices"
+	"testing"
+)
+
+func TestLtLStepper_RadiusOneMatchesConway(t *testing.T) {
+	rule, err := ddd.ParseLtL("R1,C0,M0,S2..3,B3..3,NM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, topology := range []core.Topology{core.Toroidal, core.Bounded, core.KleinBottle} {
+		life := ddd.NewGOLBoard(40, 30, topology)
+		life.SeedBoard(rng.New(5), ddd.SeedOptions{Density: 0.35})
+		ltl := ddd.NewGOLBoard(40, 30, topology)
+		ltl.CopyBoard(life.FlatSlice())
+
+		lifeEngine := NewBoardEngine(life, ddd.NewGOLBoard(40, 30, topology), NewSerialStepper(ddd.Conway))
+		ltlEngine := NewBoardEngine(ltl, ddd.NewGOLBoard(40, 30, topology), NewLtLStepper(rule))
+		for gen := 1; gen <= 30; gen++ {
+			lifeEngine.Step(1)
+			ltlEngine.Step(1)
+			if !slices.Equal(life.FlatSlice(), ltl.FlatSlice()) {
+				t.Fatalf("%s: expected R1 Moore S2..3 B3 to match Conway, but generation %d differs", topology, gen)
+			}
+		}
+	}
+}
+
+func TestLtLStepper_MajoritySmoothsNoise(t *testing.T) {
+	rule, _ := ddd.ParseLtL("Majority")
+	board := ddd.NewGOLBoard(64, 64, core.Toroidal)
+	board.SeedBoard(rng.New(2), ddd.SeedOptions{Density: 0.5})
+	e := NewBoardEngine(board, ddd.NewGOLBoard(64, 64, core.Toroidal), NewLtLStepper(rule))
+
+	changed := func() int {
+		before := slices.Clone(board.FlatSlice())
+		e.Step(1)
+		n := 0
+		for i, alive := range board.FlatSlice() {
+			if alive != before[i] {
+				n++
+			}
+		}
+		return n
+	}
+	first := changed()
+	for range 20 {
+		changed()
+	}
+	if last := changed(); last >= first {
+		t.Errorf("Expected the majority vote to settle into blobs, but %d cells changed at first and %d later", first, last)
+	}
+}
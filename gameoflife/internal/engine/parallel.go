@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"runtime"
+	"sync"
+)
+
+// minBandRows keeps bands from getting so thin that goroutine overhead wins.
+const minBandRows = 16
+
+type parallelStepper struct {
+	rule    ddd.Rule
+	workers int
+	// next[alive][neighbors] is the rule as a lookup table.
+	next [2][9]bool
+	// packed steps pairs of bit-packed boards.
+	packed *bitStepper
+}
+
+var _ Stepper = (*parallelStepper)(nil)
+
+// NewParallelStepper returns a stepper that splits the board into horizontal
+// bands of rows and computes them on up to workers goroutines. workers <= 0
+// uses one worker per CPU. Bool boards are read straight from FlatSlice; two
+// ddd.BitBoard boards are handed to the word-parallel NewBitStepper logic.
+func NewParallelStepper(rule ddd.Rule, workers int) Stepper {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	s := &parallelStepper{rule: rule, workers: workers}
+	s.packed = newBitStepper(rule, workers, s)
+	for n := 0; n <= 8; n++ {
+		s.next[0][n] = rule.Next(false, n)
+		s.next[1][n] = rule.Next(true, n)
+	}
+	return s
+}
+
+func (s *parallelStepper) Step(current, next ddd.GolBoard) {
+	_, packed := current.(ddd.BitBoard)
+	_, nextPacked := next.(ddd.BitBoard)
+	if packed && nextPacked {
+		s.packed.Step(current, next)
+		return
+	}
+
+	rows := current.Rows()
+	bands := min(s.workers, max(1, rows/minBandRows))
+
+	var wg sync.WaitGroup
+	for i := 0; i < bands; i++ {
+		y0, y1 := rows*i/bands, rows*(i+1)/bands
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.stepBand(current, next, y0, y1)
+		}()
+	}
+	wg.Wait()
+}
+
+// stepBand computes rows [y0, y1). Toroidal and bounded bool boards are read
+// straight from the backing slice; the twisted topologies, and a bit-packed
+// board paired with a bool one, go through Coordinate and SetCoordinate.
+func (s *parallelStepper) stepBand(current, next ddd.GolBoard, y0, y1 int) {
+	cols, rows := current.Cols(), current.Rows()
+	topology := current.Topology()
+	_, packed := current.(ddd.BitBoard)
+	_, nextPacked := next.(ddd.BitBoard)
+
+	if packed || nextPacked || (topology != core.Toroidal && topology != core.Bounded) {
+		for y := y0; y < y1; y++ {
+			for x := 0; x < cols; x++ {
+				alive := current.Coordinate(x, y)
+				next.SetCoordinate(x, y, s.next[b2i(alive)][current.CountSurroundingLive(x, y)])
+			}
+		}
+		return
+	}
+
+	cur, out := current.FlatSlice(), next.FlatSlice()
+
+	wrap := topology == core.Toroidal
+	// row returns the cells of row y, or nil for a row past a bounded edge.
+	row := func(y int) []bool {
+		if y < 0 || y >= rows {
+			if !wrap {
+				return nil
+			}
+			y = (y + rows) % rows
+		}
+		return cur[y*cols : (y+1)*cols]
+	}
+
+	for y := y0; y < y1; y++ {
+		above, here, below := row(y-1), row(y), row(y+1)
+		for x := 0; x < cols; x++ {
+			left, right := x-1, x+1
+			if left < 0 {
+				left = -1
+				if wrap {
+					left = cols - 1
+				}
+			}
+			if right >= cols {
+				right = -1
+				if wrap {
+					right = 0
+				}
+			}
+
+			neighbors := count(above, left, x, right) + count(here, left, -1, right) + count(below, left, x, right)
+			out[y*cols+x] = s.next[b2i(here[x])][neighbors]
+		}
+	}
+}
+
+// count adds up the live cells at the given columns of r; -1 columns and a
+// nil row count as dead.
+func count(r []bool, a, b, c int) int {
+	if r == nil {
+		return 0
+	}
+	n := 0
+	if a >= 0 && r[a] {
+		n++
+	}
+	if b >= 0 && r[b] {
+		n++
+	}
+	if c >= 0 && r[c] {
+		n++
+	}
+	return n
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
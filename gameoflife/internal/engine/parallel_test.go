@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// boardMaker builds an empty board of one GolBoard implementation.
+type boardMaker func(cols, rows int, topology core.Topology) ddd.GolBoard
+
+func boolBoard(cols, rows int, topology core.Topology) ddd.GolBoard {
+	return ddd.NewGOLBoard(cols, rows, topology)
+}
+
+func bitBoard(cols, rows int, topology core.Topology) ddd.GolBoard {
+	return ddd.NewBitBoard(cols, rows, topology)
+}
+
+// randomBoard fills a board with a fixed-seed soup so runs are repeatable.
+func randomBoard(newBoard boardMaker, cols, rows int, topology core.Topology, seed int64) ddd.GolBoard {
+	b := newBoard(cols, rows, topology)
+	r := rand.New(rand.NewSource(seed))
+	cells := make([]bool, cols*rows)
+	for i := range cells {
+		cells[i] = r.Intn(3) == 0
+	}
+	b.CopyBoard(cells)
+	return b
+}
+
+// assertSteppersAgree runs both steppers side by side for several generations;
+// want always steps bool boards and got steps boards made by gotBoard.
+func assertSteppersAgree(t *testing.T, want, got Stepper, gotBoard boardMaker, cols, rows int, topology core.Topology) {
+	t.Helper()
+	a := randomBoard(boolBoard, cols, rows, topology, int64(cols*rows))
+	b := randomBoard(gotBoard, cols, rows, topology, int64(cols*rows))
+	aNext := boolBoard(cols, rows, topology)
+	bNext := gotBoard(cols, rows, topology)
+
+	for gen := 1; gen <= 8; gen++ {
+		want.Step(a, aNext)
+		got.Step(b, bNext)
+		a, aNext = aNext, a
+		b, bNext = bNext, b
+		for y := 0; y < rows; y++ {
+			for x := 0; x < cols; x++ {
+				if a.Coordinate(x, y) != b.Coordinate(x, y) {
+					t.Fatalf("%v %dx%d generation %d: cell (%d, %d) differs", topology, cols, rows, gen, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelStepper_MatchesSerial(t *testing.T) {
+	highLife, _ := ddd.ParseRule("B36/S23")
+	topologies := []core.Topology{core.Toroidal, core.Bounded, core.KleinBottle, core.ProjectivePlane}
+	sizes := [][2]int{{1, 1}, {2, 3}, {37, 23}, {128, 97}}
+
+	for _, rule := range []ddd.Rule{ddd.Conway, highLife} {
+		for _, topology := range topologies {
+			for _, size := range sizes {
+				for _, workers := range []int{1, 3, 8} {
+					assertSteppersAgree(t, NewSerialStepper(rule), NewParallelStepper(rule, workers), boolBoard, size[0], size[1], topology)
+				}
+			}
+		}
+	}
+}
+
+func TestNewStepper(t *testing.T) {
+	if _, err := NewStepper("serial", ddd.Conway, 0); err != nil {
+		t.Errorf("Expected SERIAL to be accepted, but got %v", err)
+	}
+	if _, err := NewStepper("", ddd.Conway, 0); err != nil {
+		t.Errorf("Expected the default stepper to be accepted, but got %v", err)
+	}
+	if _, err := NewStepper("bitwise", ddd.Conway, 0); err != nil {
+		t.Errorf("Expected BITWISE to be accepted, but got %v", err)
+	}
+	if _, err := NewStepper("active", ddd.Conway, 0); err != nil {
+		t.Errorf("Expected ACTIVE to be accepted, but got %v", err)
+	}
+	if _, err := NewStepper("quantum", ddd.Conway, 0); err == nil {
+		t.Error("Expected an unknown stepper to fail, but got nil")
+	}
+}
+
+func benchmarkStepper(b *testing.B, stepper Stepper, newBoard boardMaker, size int) {
+	current := randomBoard(newBoard, size, size, core.Toroidal, 1)
+	next := newBoard(size, size, core.Toroidal)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		stepper.Step(current, next)
+		current, next = next, current
+	}
+}
+
+// BenchmarkSteppers compares the serial, parallel and bitwise steppers on
+// 1k x 1k and 4k x 4k boards: go test -bench Steppers ./gameoflife/internal/engine
+func BenchmarkSteppers(b *testing.B) {
+	for _, size := range []int{1024, 4096} {
+		b.Run(fmt.Sprintf("serial/%d", size), func(b *testing.B) {
+			benchmarkStepper(b, NewSerialStepper(ddd.Conway), boolBoard, size)
+		})
+		b.Run(fmt.Sprintf("parallel/%d", size), func(b *testing.B) {
+			benchmarkStepper(b, NewParallelStepper(ddd.Conway, 0), boolBoard, size)
+		})
+		b.Run(fmt.Sprintf("bitwise/%d", size), func(b *testing.B) {
+			benchmarkStepper(b, NewBitStepper(ddd.Conway, 0), bitBoard, size)
+		})
+	}
+}
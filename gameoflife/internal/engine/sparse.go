@@ -0,0 +1,112 @@
+package engine
+
+import "SideProjectGames/gameoflife/internal/ddd"
+
+// SparseStepper advances an unbounded SparseBoard one generation at a time.
+// Only stored tiles and the tiles touching them are evaluated, a whole tile
+// row (64 cells) at a time.
+type SparseStepper struct {
+	lanes laneRule
+}
+
+// NewSparseStepper returns a stepper for rule. It cannot simulate B0 rules,
+// so callers reject them first with ErrBirthOnZero.
+func NewSparseStepper(rule ddd.Rule) *SparseStepper {
+	return &SparseStepper{lanes: newLaneRule(rule)}
+}
+
+// Step clears next and writes the generation after current into it.
+func (s *SparseStepper) Step(current, next ddd.SparseBoard) {
+	next.Clear()
+
+	candidates := make(map[ddd.TileKey]struct{})
+	for _, key := range current.Tiles() {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				candidates[ddd.TileKey{X: key.X + dx, Y: key.Y + dy}] = struct{}{}
+			}
+		}
+	}
+
+	for key := range candidates {
+		next.SetTile(key, s.stepTile(current, key))
+	}
+}
+
+// stepTile computes the next state of one tile from it and its 8 neighbours.
+func (s *SparseStepper) stepTile(current ddd.SparseBoard, key ddd.TileKey) ddd.Tile {
+	var around [3][3]ddd.Tile
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			around[dy+1][dx+1], _ = current.Tile(ddd.TileKey{X: key.X + dx, Y: key.Y + dy})
+		}
+	}
+
+	// row returns tile row y of the west, middle and east tiles, where -1
+	// and TileSize reach into the tiles above and below.
+	row := func(y int) [3]uint64 {
+		ty := 1
+		if y < 0 {
+			ty, y = 0, y+ddd.TileSize
+		} else if y >= ddd.TileSize {
+			ty, y = 2, y-ddd.TileSize
+		}
+		return [3]uint64{around[ty][0][y], around[ty][1][y], around[ty][2][y]}
+	}
+
+	var out ddd.Tile
+	for y := 0; y < ddd.TileSize; y++ {
+		above, here, below := row(y-1), row(y), row(y+1)
+		aW, a, aE := shifted(above[:], 1)
+		hW, h, hE := shifted(here[:], 1)
+		bW, b, bE := shifted(below[:], 1)
+		c0, c1, c2, c3 := add8(aW, a, aE, hW, hE, bW, b, bE)
+		out[y] = s.lanes.next(h, c0, c1, c2, c3)
+	}
+	return out
+}
+
+// SparseEngine adapts a SparseBoard and SparseStepper to the Engine interface.
+type SparseEngine struct {
+	current    ddd.SparseBoard
+	next       ddd.SparseBoard
+	stepper    *SparseStepper
+	generation int64
+}
+
+var _ Engine = (*SparseEngine)(nil)
+
+// NewSparseEngine returns an Engine that owns board and advances it with rule.
+// Board returns the current generation after each Step.
+func NewSparseEngine(board ddd.SparseBoard, rule ddd.Rule) *SparseEngine {
+	return &SparseEngine{current: board, next: ddd.NewSparseBoard(), stepper: NewSparseStepper(rule)}
+}
+
+func (e *SparseEngine) Board() ddd.SparseBoard {
+	return e.current
+}
+
+func (e *SparseEngine) Step(generations int64) {
+	for i := int64(0); i < generations; i++ {
+		e.stepper.Step(e.current, e.next)
+		e.current, e.next = e.next, e.current
+	}
+	e.generation += generations
+}
+
+func (e *SparseEngine) Generation() int64 {
+	return e.generation
+}
+
+func (e *SparseEngine) Population() int64 {
+	return int64(e.current.Population())
+}
+
+func (e *SparseEngine) Bounds() (minX, minY, maxX, maxY int64, ok bool) {
+	x0, y0, x1, y1, ok := e.current.Bounds()
+	return int64(x0), int64(y0), int64(x1), int64(y1), ok
+}
+
+func (e *SparseEngine) Alive(x, y int64) bool {
+	return e.current.Coordinate(int(x), int(y))
+}
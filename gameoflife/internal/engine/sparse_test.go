@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"strings"
+	"testing"
+)
+
+func TestSparseEngine_MatchesBoundedBoard(t *testing.T) {
+	// An R-pentomino straddling the origin, so it spreads over negative tiles too.
+	p, err := ddd.ReadRLE(strings.NewReader("x = 3, y = 3\nb2o$2o$bo!"))
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	const size, offset = 256, 128
+	board := ddd.NewGOLBoard(size, size, core.Bounded)
+	p.Place(board, offset, offset)
+	want := NewBoardEngine(board, ddd.NewGOLBoard(size, size, core.Bounded), NewSerialStepper(ddd.Conway))
+
+	sparse := ddd.NewSparseBoard()
+	p.Place(sparse, 0, 0)
+	got := NewSparseEngine(sparse, ddd.Conway)
+
+	for gen := 0; gen < 150; gen += 10 {
+		want.Step(10)
+		got.Step(10)
+		if got.Population() != want.Population() {
+			t.Fatalf("Generation %d: expected population %d, but got %d", gen+10, want.Population(), got.Population())
+		}
+		for y := int64(0); y < size; y++ {
+			for x := int64(0); x < size; x++ {
+				if got.Alive(x-offset, y-offset) != want.Alive(x, y) {
+					t.Fatalf("Generation %d: cell (%d, %d) differs", gen+10, x-offset, y-offset)
+				}
+			}
+		}
+	}
+}
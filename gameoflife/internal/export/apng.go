@@ -0,0 +1,124 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"time"
+)
+
+// pngSignature starts every PNG file.
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// chunk is one PNG chunk.
+type chunk struct {
+	kind string
+	data []byte
+}
+
+// writeAPNG writes n frames, which must share a size and palette, as an
+// animated PNG that loops forever. frame renders frame i; frames are rendered
+// and written one at a time, so only one is held in memory. The standard
+// library has no APNG encoder, so each frame is encoded as a PNG and its image
+// data rewrapped: the first frame's IDAT chunks become the default image and
+// the others become fdAT chunks, each frame preceded by an fcTL chunk with its
+// timing.
+func writeAPNG(w io.Writer, n int, frame func(i int) *image.Paletted, delay time.Duration) error {
+	out := bufio.NewWriter(w)
+	out.Write(pngSignature)
+	sequence := uint32(0)
+	ms := uint16(min(max(delay.Milliseconds(), 1), 0xffff))
+
+	for i := range n {
+		img := frame(i)
+		chunks, err := encodeChunks(img)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			// Everything before the image data (IHDR, PLTE) is shared by
+			// every frame; acTL has to come before the first IDAT.
+			for _, c := range chunks {
+				if c.kind == "IDAT" {
+					break
+				}
+				writeChunk(out, c)
+				if c.kind == "IHDR" {
+					writeChunk(out, chunk{"acTL", be32(uint32(n), 0)})
+				}
+			}
+		}
+
+		b := img.Bounds()
+		control := be32(sequence, uint32(b.Dx()), uint32(b.Dy()), 0, 0)
+		control = binary.BigEndian.AppendUint16(control, ms)
+		control = binary.BigEndian.AppendUint16(control, 1000)
+		// Dispose op none and blend op source: each frame replaces the last.
+		control = append(control, 0, 0)
+		writeChunk(out, chunk{"fcTL", control})
+		sequence++
+
+		for _, c := range chunks {
+			if c.kind != "IDAT" {
+				continue
+			}
+			if i == 0 {
+				writeChunk(out, c)
+				continue
+			}
+			writeChunk(out, chunk{"fdAT", append(be32(sequence), c.data...)})
+			sequence++
+		}
+	}
+	writeChunk(out, chunk{"IEND", nil})
+	return out.Flush()
+}
+
+// encodeChunks encodes img as a PNG and splits it into chunks.
+func encodeChunks(img image.Image) ([]chunk, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errors.New("export: PNG encoder wrote no signature")
+	}
+	data = data[len(pngSignature):]
+
+	var chunks []chunk
+	for len(data) >= 12 {
+		n := binary.BigEndian.Uint32(data)
+		if uint64(len(data)) < 12+uint64(n) {
+			break
+		}
+		chunks = append(chunks, chunk{kind: string(data[4:8]), data: data[8 : 8+n]})
+		data = data[12+n:]
+	}
+	return chunks, nil
+}
+
+// writeChunk writes c to w; a bufio.Writer keeps the first error for Flush.
+func writeChunk(w *bufio.Writer, c chunk) {
+	w.Write(be32(uint32(len(c.data))))
+	crc := crc32.NewIEEE()
+	crc.Write([]byte(c.kind))
+	crc.Write(c.data)
+	w.WriteString(c.kind)
+	w.Write(c.data)
+	w.Write(be32(crc.Sum32()))
+}
+
+// be32 encodes values as consecutive big-endian uint32s.
+func be32(values ...uint32) []byte {
+	out := make([]byte, 0, 4*len(values))
+	for _, v := range values {
+		out = binary.BigEndian.AppendUint32(out, v)
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+// Package export renders Game of Life boards to images with the standard
+// image packages, so it works headlessly: single generations as PNG and
+// recorded runs as animated GIF or APNG. It does not depend on Ebiten.
+package export
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	core "SideProjectGames/internal/ddd"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// Style is how cells are drawn.
+type Style struct {
+	// CellSize is the width and height of a cell in pixels.
+	CellSize int
+	// Grid draws the last pixel row and column of each cell in GridLine,
+	// like the gap the game leaves between cells. It needs a CellSize of at
+	// least 2.
+	Grid       bool
+	Background color.Color
+	Live       color.Color
+	GridLine   color.Color
+}
+
+// DefaultStyle matches the game window: white cells on black, with the grey
+// of the board edge for grid lines.
+var DefaultStyle = Style{
+	CellSize:   4,
+	Background: color.RGBA{A: 255},
+	Live:       color.RGBA{R: 255, G: 255, B: 255, A: 255},
+	GridLine:   color.RGBA{R: 80, G: 80, B: 80, A: 255},
+}
+
+// Palette indices of the images Render returns.
+const (
+	backgroundIndex uint8 = iota
+	liveIndex
+	gridIndex
+)
+
+func (s Style) palette() color.Palette {
+	return color.Palette{s.Background, s.Live, s.GridLine}
+}
+
+// Fits reports whether a width x height block of cells drawn in s takes at
+// most maxPixels pixels.
+func (s Style) Fits(width, height, maxPixels int) bool {
+	size := max(s.CellSize, 1)
+	return width <= maxPixels/size/size/max(height, 1)
+}
+
+// Render draws the width x height block of cells starting at (x, y).
+func Render(cells core.Grid[bool], x, y, width, height int, style Style) *image.Paletted {
+	return render(width, height, style, func(cx, cy int) bool {
+		return cells.Coordinate(x+cx, y+cy)
+	})
+}
+
+// RenderBoard draws the whole of b.
+func RenderBoard(b ddd.GolBoard, style Style) *image.Paletted {
+	return Render(b, 0, 0, b.Cols(), b.Rows(), style)
+}
+
+// RenderPattern draws p.
+func RenderPattern(p *ddd.Pattern, style Style) *image.Paletted {
+	return render(p.Width, p.Height, style, p.Alive)
+}
+
+func render(width, height int, style Style, alive func(x, y int) bool) *image.Paletted {
+	img := blank(width, height, style)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if alive(x, y) {
+				fill(img, x, y, style)
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG encodes img as a PNG.
+func WritePNG(w io.Writer, img image.Image) error {
+	return png.Encode(w, img)
+}
+
+// blank returns an image of width x height empty cells, with grid lines if
+// the style asks for them.
+func blank(width, height int, style Style) *image.Paletted {
+	size := max(style.CellSize, 1)
+	img := image.NewPaletted(image.Rect(0, 0, max(width, 1)*size, max(height, 1)*size), style.palette())
+	if !style.Grid || size < 2 {
+		return img
+	}
+	bounds := img.Bounds()
+	for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+		for px := bounds.Min.X; px < bounds.Max.X; px++ {
+			if px%size == size-1 || py%size == size-1 {
+				img.SetColorIndex(px, py, gridIndex)
+			}
+		}
+	}
+	return img
+}
+
+// fill paints the cell at (x, y) of img live, leaving its grid lines.
+func fill(img *image.Paletted, x, y int, style Style) {
+	size := max(style.CellSize, 1)
+	inner := size
+	if style.Grid && size >= 2 {
+		inner--
+	}
+	for py := y * size; py < y*size+inner; py++ {
+		row := img.Pix[py*img.Stride:]
+		for px := x * size; px < x*size+inner; px++ {
+			row[px] = liveIndex
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package export
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	core "SideProjectGames/internal/ddd"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func glider() ddd.GolBoard {
+	b := ddd.NewGOLBoard(8, 6, core.Bounded)
+	for _, c := range [][2]int{{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}} {
+		b.SetCoordinate(c[0], c[1], true)
+	}
+	return b
+}
+
+func TestRenderBoard(t *testing.T) {
+	img := RenderBoard(glider(), DefaultStyle)
+	if got := img.Bounds(); got != image.Rect(0, 0, 32, 24) {
+		t.Fatalf("Expected a 32x24 image, but got %v", got)
+	}
+	if got := img.At(5, 1); got != DefaultStyle.Live {
+		t.Errorf("Expected the live cell (1, 0) drawn in the live colour, but got %v", got)
+	}
+	if got := img.At(1, 1); got != DefaultStyle.Background {
+		t.Errorf("Expected the dead cell (0, 0) drawn in the background colour, but got %v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePNG(&buf, img); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if decoded, err := png.Decode(&buf); err != nil || decoded.Bounds() != img.Bounds() {
+		t.Errorf("Expected a readable 32x24 PNG, but got %v", err)
+	}
+}
+
+func TestRender_Grid(t *testing.T) {
+	style := DefaultStyle
+	style.Grid = true
+	img := RenderBoard(glider(), style)
+
+	if got := img.At(7, 1); got != style.GridLine {
+		t.Errorf("Expected the last column of a cell to be a grid line, but got %v", got)
+	}
+	if got := img.At(5, 3); got != style.GridLine {
+		t.Errorf("Expected the last row of a live cell to stay a grid line, but got %v", got)
+	}
+	if got := img.At(6, 2); got != style.Live {
+		t.Errorf("Expected the inside of a live cell to be live, but got %v", got)
+	}
+}
+
+// run records gens generations of a glider on an unbounded board.
+func run(r Recorder, gens int) {
+	b := ddd.NewSparseBoard()
+	for _, c := range [][2]int{{1, 0}, {2, 1}, {0, 2}, {1, 2}, {2, 2}} {
+		b.SetCoordinate(c[0], c[1], true)
+	}
+	e := engine.NewSparseEngine(b, ddd.Conway)
+	for range gens {
+		r.Add(engine.SparseLiveCells(e.Board()))
+		e.Step(1)
+	}
+}
+
+func TestRecorder_CropsToEveryFrame(t *testing.T) {
+	opts := DefaultRecordOptions
+	opts.Style.CellSize = 1
+	r := NewRecorder(opts)
+	run(r, 9)
+
+	if r.Len() != 9 {
+		t.Fatalf("Expected 9 frames, but got %d", r.Len())
+	}
+	// The glider moves two cells in eight generations, so the crop covers a
+	// 5x5 box in every frame.
+	for i := range r.Len() {
+		if f := r.Frame(i); f.Bounds() != image.Rect(0, 0, 5, 5) {
+			t.Fatalf("Expected frame %d to be 5x5, but got %v", i, f.Bounds())
+		}
+	}
+	if last := r.Frame(8); last.At(3, 2) != opts.Style.Live || last.At(1, 0) == opts.Style.Live {
+		t.Error("Expected the last frame to show the glider moved by (2, 2)")
+	}
+}
+
+func TestRecorder_MaxFrames(t *testing.T) {
+	opts := DefaultRecordOptions
+	opts.MaxFrames = 3
+	r := NewRecorder(opts)
+	run(r, 5)
+	if r.Len() != 3 || !r.Full() {
+		t.Errorf("Expected a full recording of 3 frames, but got %d", r.Len())
+	}
+	if r.Add(nil) {
+		t.Error("Expected Add to refuse a frame once full")
+	}
+}
+
+func TestRecorder_PixelAndCellBudgets(t *testing.T) {
+	// A 2000x2000 board at 4 pixels a cell is 64M pixels a frame.
+	opts := DefaultRecordOptions
+	opts.Style.CellSize = 4
+	opts.Crop = false
+	opts.Width, opts.Height = 2000, 2000
+	r := NewRecorder(opts)
+	if r.Limit() != DefaultMaxPixels/(2000*2000*16) {
+		t.Errorf("Expected the pixel budget to limit the recording to %d frames, but got %d", DefaultMaxPixels/(2000*2000*16), r.Limit())
+	}
+	run(r, 5)
+	if r.Len() != r.Limit() || !r.Full() {
+		t.Errorf("Expected the recording to fill up at %d frames, but got %d", r.Limit(), r.Len())
+	}
+
+	opts.MaxPixels = 1000
+	r = NewRecorder(opts)
+	if r.Add(nil) {
+		t.Error("Expected a frame over the pixel budget to be refused")
+	}
+	if err := r.WriteGIF(&bytes.Buffer{}); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, but got %v", err)
+	}
+
+	opts = DefaultRecordOptions
+	opts.MaxCells = 12
+	r = NewRecorder(opts)
+	run(r, 5)
+	if r.Len() != 2 || !r.Full() {
+		t.Errorf("Expected room for two 5-cell gliders in 12 cells, but got %d frames", r.Len())
+	}
+}
+
+func TestRecorder_Area(t *testing.T) {
+	opts := DefaultRecordOptions
+	opts.Style.CellSize = 2
+	opts.Crop = false
+	opts.Width, opts.Height = 10, 7
+	r := NewRecorder(opts)
+	run(r, 1)
+	if got := r.Frame(0).Bounds(); got != image.Rect(0, 0, 20, 14) {
+		t.Errorf("Expected the fixed 10x7 area at 2 pixels a cell, but got %v", got)
+	}
+}
+
+func TestRecorder_WriteGIF(t *testing.T) {
+	opts := DefaultRecordOptions
+	opts.Delay = 50 * time.Millisecond
+	r := NewRecorder(opts)
+	if err := r.WriteGIF(&bytes.Buffer{}); !errors.Is(err, ErrNoFrames) {
+		t.Errorf("Expected ErrNoFrames for an empty recording, but got %v", err)
+	}
+	run(r, 4)
+
+	var buf bytes.Buffer
+	if err := r.WriteGIF(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("Expected a readable GIF, but got %v", err)
+	}
+	if len(decoded.Image) != 4 || decoded.Delay[0] != 5 {
+		t.Errorf("Expected 4 frames of 5/100 s, but got %d frames of %v", len(decoded.Image), decoded.Delay)
+	}
+}
+
+func TestRecorder_WriteAPNG(t *testing.T) {
+	r := NewRecorder(DefaultRecordOptions)
+	run(r, 4)
+
+	var buf bytes.Buffer
+	if err := r.WriteAPNG(&buf); err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	data := buf.Bytes()
+	// Viewers without APNG support show the first frame, so it must be a
+	// valid PNG with valid checksums.
+	if _, err := png.Decode(bytes.NewReader(data)); err != nil {
+		t.Fatalf("Expected a readable PNG, but got %v", err)
+	}
+
+	counts := make(map[string]int)
+	var frames uint32
+	for rest := data[len(pngSignature):]; len(rest) >= 12; {
+		n := binary.BigEndian.Uint32(rest)
+		kind := string(rest[4:8])
+		if kind == "acTL" {
+			frames = binary.BigEndian.Uint32(rest[8:])
+		}
+		counts[kind]++
+		rest = rest[12+n:]
+	}
+	if frames != 4 || counts["fcTL"] != 4 || counts["fdAT"] < 3 || counts["IDAT"] < 1 {
+		t.Errorf("Expected 4 frames with 4 fcTL chunks and data for each, but got acTL %d and %v", frames, counts)
+	}
+}
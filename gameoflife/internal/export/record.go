@@ -0,0 +1,208 @@
+package export
+
+import (
+	"SideProjectGames/gameoflife/internal/engine"
+	"errors"
+	"image"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// RecordOptions describe an animation.
+type RecordOptions struct {
+	Style Style
+	// Delay is how long each frame is shown.
+	Delay time.Duration
+	// MaxFrames is the most frames kept; later frames are dropped. Zero means
+	// DefaultMaxFrames.
+	MaxFrames int
+	// MaxPixels bounds the pixels of every frame added together, which is
+	// what a GIF holds in memory while it is encoded, and MaxCells the live
+	// cells kept for all the frames. A frame that would go over either is
+	// dropped, as are all after it. Zero means DefaultMaxPixels and
+	// DefaultMaxCells.
+	MaxPixels int
+	MaxCells  int
+	// Crop fits the animation to the bounding box of every live cell seen in
+	// any frame. Otherwise it shows the Width x Height area at (X, Y), which
+	// is usually the whole board. Crop is always used when the area is empty.
+	Crop          bool
+	X, Y          int
+	Width, Height int
+}
+
+// Defaults for the limits of a recording. DefaultMaxPixels is 128 MB of
+// frames and DefaultMaxCells 64 MB of live cells.
+const (
+	DefaultMaxFrames = 500
+	DefaultMaxPixels = 1 << 27
+	DefaultMaxCells  = 1 << 22
+)
+
+// DefaultRecordOptions record up to DefaultMaxFrames generations at ten
+// frames a second, cropped to the live cells.
+var DefaultRecordOptions = RecordOptions{
+	Style:     DefaultStyle,
+	Delay:     100 * time.Millisecond,
+	MaxFrames: DefaultMaxFrames,
+	Crop:      true,
+}
+
+var (
+	// ErrNoFrames is returned when writing a recording that has no frames.
+	ErrNoFrames = errors.New("export: nothing recorded")
+	// ErrTooLarge is returned when writing a recording whose first frame was
+	// already over its MaxPixels or MaxCells.
+	ErrTooLarge = errors.New("export: a single frame is over the recording's size limit; use a smaller cell size or crop")
+)
+
+// Recorder collects generations and writes them as an animation. It keeps
+// only the live cells of each frame and renders them when written, so the
+// crop can cover the whole run.
+type Recorder interface {
+	// Add records one generation. It returns false, keeping nothing, once
+	// MaxFrames frames have been recorded or the frame would go over
+	// MaxPixels or MaxCells.
+	Add(cells []engine.Cell) bool
+	Len() int
+	Full() bool
+	// Limit is the most frames the recording can hold at its current size.
+	Limit() int
+	// Frame renders frame i. Every frame has the same size.
+	Frame(i int) *image.Paletted
+	// WriteGIF renders every frame before encoding them, up to MaxPixels.
+	WriteGIF(w io.Writer) error
+	// WriteAPNG renders and writes one frame at a time.
+	WriteAPNG(w io.Writer) error
+}
+
+type recorder struct {
+	opts   RecordOptions
+	frames [][]engine.Cell
+	cells  int
+	// overflow is set once a frame went over MaxPixels or MaxCells.
+	overflow bool
+	bounds
+}
+
+// bounds is the bounding box of every live cell recorded; ok is false until
+// one has been.
+type bounds struct {
+	minX, minY, maxX, maxY int
+	ok                     bool
+}
+
+var _ Recorder = (*recorder)(nil)
+
+// NewRecorder returns an empty recording.
+func NewRecorder(opts RecordOptions) Recorder {
+	if opts.MaxFrames <= 0 {
+		opts.MaxFrames = DefaultMaxFrames
+	}
+	if opts.MaxPixels <= 0 {
+		opts.MaxPixels = DefaultMaxPixels
+	}
+	if opts.MaxCells <= 0 {
+		opts.MaxCells = DefaultMaxCells
+	}
+	return &recorder{opts: opts}
+}
+
+func (r *recorder) Add(cells []engine.Cell) bool {
+	if r.Full() {
+		return false
+	}
+	grown := r.bounds
+	for _, c := range cells {
+		if !grown.ok {
+			grown = bounds{c.X, c.Y, c.X, c.Y, true}
+			continue
+		}
+		grown.minX, grown.maxX = min(grown.minX, c.X), max(grown.maxX, c.X)
+		grown.minY, grown.maxY = min(grown.minY, c.Y), max(grown.maxY, c.Y)
+	}
+	// A cropped area can grow with this frame, making every frame bigger.
+	if r.cells+len(cells) > r.opts.MaxCells || r.framePixels(grown) > r.opts.MaxPixels/(len(r.frames)+1) {
+		r.overflow = true
+		return false
+	}
+	r.bounds = grown
+	r.cells += len(cells)
+	r.frames = append(r.frames, cells)
+	return true
+}
+
+func (r *recorder) Len() int {
+	return len(r.frames)
+}
+
+func (r *recorder) Full() bool {
+	return r.overflow || len(r.frames) >= r.opts.MaxFrames
+}
+
+func (r *recorder) Limit() int {
+	return min(r.opts.MaxFrames, r.opts.MaxPixels/r.framePixels(r.bounds))
+}
+
+// area is the block of cells every frame shows when the live cells recorded
+// are within b.
+func (r *recorder) area(b bounds) (x, y, width, height int) {
+	if !r.opts.Crop && r.opts.Width > 0 && r.opts.Height > 0 {
+		return r.opts.X, r.opts.Y, r.opts.Width, r.opts.Height
+	}
+	if !b.ok {
+		return 0, 0, 1, 1
+	}
+	return b.minX, b.minY, b.maxX - b.minX + 1, b.maxY - b.minY + 1
+}
+
+// framePixels is the size of one frame showing the area for b.
+func (r *recorder) framePixels(b bounds) int {
+	_, _, width, height := r.area(b)
+	size := max(r.opts.Style.CellSize, 1)
+	return width * size * height * size
+}
+
+func (r *recorder) Frame(i int) *image.Paletted {
+	x, y, width, height := r.area(r.bounds)
+	img := blank(width, height, r.opts.Style)
+	for _, c := range r.frames[i] {
+		if c.X >= x && c.X < x+width && c.Y >= y && c.Y < y+height {
+			fill(img, c.X-x, c.Y-y, r.opts.Style)
+		}
+	}
+	return img
+}
+
+// empty is the error for writing a recording with no frames.
+func (r *recorder) empty() error {
+	if r.overflow {
+		return ErrTooLarge
+	}
+	return ErrNoFrames
+}
+
+func (r *recorder) WriteGIF(w io.Writer) error {
+	if len(r.frames) == 0 {
+		return r.empty()
+	}
+	images := make([]*image.Paletted, len(r.frames))
+	for i := range images {
+		images[i] = r.Frame(i)
+	}
+	// GIF delays are in hundredths of a second.
+	delay := max(int(r.opts.Delay/(10*time.Millisecond)), 1)
+	delays := make([]int, len(images))
+	for i := range delays {
+		delays[i] = delay
+	}
+	return gif.EncodeAll(w, &gif.GIF{Image: images, Delay: delays})
+}
+
+func (r *recorder) WriteAPNG(w io.Writer) error {
+	if len(r.frames) == 0 {
+		return r.empty()
+	}
+	return writeAPNG(w, len(r.frames), r.Frame, r.opts.Delay)
+}
@@ -0,0 +1,121 @@
+package hashlife
+
+// node is a canonical quadtree node. A level-k node covers a 2^k x 2^k square;
+// level 0 nodes are single cells. Nodes are immutable and hash-consed, so two
+// equal subtrees are always the same pointer and results can be memoized on
+// the node itself.
+type node struct {
+	nw, ne, sw, se *node
+	level          uint8
+	alive          bool
+	population     int64
+	// next caches the centre of this node advanced by 2^(level-2) generations.
+	next *node
+}
+
+type quad [4]*node
+
+type stepKey struct {
+	n *node
+	j uint8
+}
+
+// store owns the canonical node table and the memoized results.
+type store struct {
+	dead, live *node
+	nodes      map[quad]*node
+	steps      map[stepKey]*node
+	empties    []*node
+}
+
+func newStore() *store {
+	s := &store{
+		dead:  &node{},
+		live:  &node{alive: true, population: 1},
+		nodes: make(map[quad]*node),
+		steps: make(map[stepKey]*node),
+	}
+	s.empties = []*node{s.dead}
+	return s
+}
+
+func (s *store) leaf(alive bool) *node {
+	if alive {
+		return s.live
+	}
+	return s.dead
+}
+
+// join returns the canonical node with the given four children.
+func (s *store) join(nw, ne, sw, se *node) *node {
+	key := quad{nw, ne, sw, se}
+	if n, ok := s.nodes[key]; ok {
+		return n
+	}
+	n := &node{
+		nw: nw, ne: ne, sw: sw, se: se,
+		level:      nw.level + 1,
+		population: nw.population + ne.population + sw.population + se.population,
+	}
+	s.nodes[key] = n
+	return n
+}
+
+// empty returns the canonical all-dead node of the given level.
+func (s *store) empty(level uint8) *node {
+	for int(level) >= len(s.empties) {
+		e := s.empties[len(s.empties)-1]
+		s.empties = append(s.empties, s.join(e, e, e, e))
+	}
+	return s.empties[level]
+}
+
+// centre returns the level k-1 node in the middle of n.
+func (s *store) centre(n *node) *node {
+	return s.join(n.nw.se, n.ne.sw, n.sw.ne, n.se.nw)
+}
+
+// horizontal returns the level k-1 node straddling the seam between w and e.
+func (s *store) horizontal(w, e *node) *node {
+	return s.join(w.ne, e.nw, w.se, e.sw)
+}
+
+// vertical returns the level k-1 node straddling the seam between n and south.
+func (s *store) vertical(n, south *node) *node {
+	return s.join(n.sw, n.se, south.nw, south.ne)
+}
+
+// size returns the number of canonical nodes held.
+func (s *store) size() int {
+	return len(s.nodes)
+}
+
+// compact drops every memoized result and every node not reachable from
+// roots, keeping memory bounded on long runs.
+func (s *store) compact(roots ...*node) {
+	nodes := make(map[quad]*node, len(s.nodes)/2)
+	var keep func(n *node)
+	keep = func(n *node) {
+		if n.level == 0 {
+			return
+		}
+		key := quad{n.nw, n.ne, n.sw, n.se}
+		if _, ok := nodes[key]; ok {
+			return
+		}
+		n.next = nil
+		nodes[key] = n
+		keep(n.nw)
+		keep(n.ne)
+		keep(n.sw)
+		keep(n.se)
+	}
+	for _, r := range roots {
+		keep(r)
+	}
+	for _, e := range s.empties {
+		keep(e)
+	}
+	s.nodes = nodes
+	s.steps = make(map[stepKey]*node)
+}
@@ -0,0 +1,368 @@
+package hashlife
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"math/bits"
+)
+
+// maxNodes is the canonical table size above which memoized results are
+// dropped before the next step.
+const maxNodes = 1 << 22
+
+// maxLevel keeps every coordinate inside an int64.
+const maxLevel = 62
+
+// Universe is an unbounded Game of Life universe advanced with Gosper's
+// HashLife algorithm: a memoized quadtree where identical regions are stored
+// once and their futures are computed once.
+type Universe struct {
+	store      *store
+	rule       ddd.Rule
+	root       *node
+	originX    int64 // top-left corner of root
+	originY    int64
+	generation int64
+}
+
+var _ engine.Engine = (*Universe)(nil)
+
+// New returns an empty universe running rule. B0 rules fail with
+// engine.ErrBirthOnZero.
+func New(rule ddd.Rule) (*Universe, error) {
+	if rule.Born(0) {
+		return nil, engine.ErrBirthOnZero
+	}
+	s := newStore()
+	u := &Universe{store: s, rule: rule, root: s.empty(3)}
+	u.originX, u.originY = -4, -4
+	return u, nil
+}
+
+// FromBoard builds a universe from the live cells of b, keeping their coordinates.
+func FromBoard(b ddd.GolBoard, rule ddd.Rule) (*Universe, error) {
+	u, err := New(rule)
+	if err != nil {
+		return nil, err
+	}
+	for y := 0; y < b.Rows(); y++ {
+		for x := 0; x < b.Cols(); x++ {
+			if b.Coordinate(x, y) {
+				u.Set(int64(x), int64(y), true)
+			}
+		}
+	}
+	return u, nil
+}
+
+// FromPattern builds a universe with p's top-left corner at the origin.
+func FromPattern(p *ddd.Pattern, rule ddd.Rule) (*Universe, error) {
+	u, err := New(rule)
+	if err != nil {
+		return nil, err
+	}
+	for y := 0; y < p.Height; y++ {
+		for x := 0; x < p.Width; x++ {
+			if p.Alive(x, y) {
+				u.Set(int64(x), int64(y), true)
+			}
+		}
+	}
+	return u, nil
+}
+
+func (u *Universe) Rule() ddd.Rule {
+	return u.rule
+}
+
+func (u *Universe) Generation() int64 {
+	return u.generation
+}
+
+func (u *Universe) Population() int64 {
+	return u.root.population
+}
+
+func (u *Universe) size() int64 {
+	return int64(1) << u.root.level
+}
+
+func (u *Universe) contains(x, y int64) bool {
+	return x >= u.originX && x < u.originX+u.size() && y >= u.originY && y < u.originY+u.size()
+}
+
+// Alive reports whether the cell at (x, y) is alive.
+func (u *Universe) Alive(x, y int64) bool {
+	if !u.contains(x, y) {
+		return false
+	}
+	n := u.root
+	x, y = x-u.originX, y-u.originY
+	for n.level > 0 {
+		if n.population == 0 {
+			return false
+		}
+		half := int64(1) << (n.level - 1)
+		switch {
+		case x < half && y < half:
+			n = n.nw
+		case y < half:
+			n, x = n.ne, x-half
+		case x < half:
+			n, y = n.sw, y-half
+		default:
+			n, x, y = n.se, x-half, y-half
+		}
+	}
+	return n.alive
+}
+
+// Set changes the cell at (x, y), growing the universe as needed.
+func (u *Universe) Set(x, y int64, alive bool) {
+	for !u.contains(x, y) {
+		if !alive {
+			return
+		}
+		u.expand()
+	}
+	u.root = u.set(u.root, x-u.originX, y-u.originY, alive)
+}
+
+func (u *Universe) set(n *node, x, y int64, alive bool) *node {
+	if n.level == 0 {
+		return u.store.leaf(alive)
+	}
+	half := int64(1) << (n.level - 1)
+	nw, ne, sw, se := n.nw, n.ne, n.sw, n.se
+	switch {
+	case x < half && y < half:
+		nw = u.set(nw, x, y, alive)
+	case y < half:
+		ne = u.set(ne, x-half, y, alive)
+	case x < half:
+		sw = u.set(sw, x, y-half, alive)
+	default:
+		se = u.set(se, x-half, y-half, alive)
+	}
+	return u.store.join(nw, ne, sw, se)
+}
+
+// expand doubles the universe, keeping the current root in the middle.
+func (u *Universe) expand() {
+	if u.root.level >= maxLevel {
+		panic("hashlife: universe outgrew int64 coordinates")
+	}
+	s := u.store
+	r := u.root
+	e := s.empty(r.level - 1)
+	u.root = s.join(
+		s.join(e, e, e, r.nw),
+		s.join(e, e, r.ne, e),
+		s.join(e, r.sw, e, e),
+		s.join(r.se, e, e, e),
+	)
+	half := int64(1) << (r.level - 1)
+	u.originX -= half
+	u.originY -= half
+}
+
+// paddedOnly reports whether every live cell of the root lies in its central
+// half, i.e. the twelve outer grandchildren are empty.
+func (u *Universe) paddedOnly() bool {
+	r := u.root
+	return r.nw.nw.population+r.nw.ne.population+r.nw.sw.population+
+		r.ne.nw.population+r.ne.ne.population+r.ne.se.population+
+		r.sw.nw.population+r.sw.sw.population+r.sw.se.population+
+		r.se.ne.population+r.se.sw.population+r.se.se.population == 0
+}
+
+// Advance moves the universe forward by exactly 2^k generations.
+func (u *Universe) Advance(k uint) {
+	if k > maxLevel-3 {
+		panic("hashlife: step exponent too large")
+	}
+	if u.store.size() > maxNodes {
+		u.store.compact(u.root)
+	}
+
+	// Make sure nothing can escape the centre the successor returns: the live
+	// cells must sit in the central half with room for light-speed growth.
+	for u.root.level < uint8(k)+2 || !u.paddedOnly() {
+		u.expand()
+	}
+	u.expand()
+
+	quarter := int64(1) << (u.root.level - 2)
+	u.root = u.successor(u.root, uint8(k))
+	u.originX += quarter
+	u.originY += quarter
+	u.generation += int64(1) << k
+}
+
+// Step advances the universe by n generations, one power of two at a time.
+func (u *Universe) Step(n int64) {
+	for n > 0 {
+		k := uint(bits.TrailingZeros64(uint64(n)))
+		u.Advance(k)
+		n -= int64(1) << k
+	}
+}
+
+// successor returns the centre of n (level k-1) advanced by 2^j generations,
+// where j <= k-2.
+func (u *Universe) successor(n *node, j uint8) *node {
+	s := u.store
+	if n.population == 0 {
+		return s.empty(n.level - 1)
+	}
+	if n.level == 2 {
+		return u.base(n)
+	}
+
+	full := j == n.level-2
+	if full && n.next != nil {
+		return n.next
+	}
+	if !full {
+		if r, ok := s.steps[stepKey{n, j}]; ok {
+			return r
+		}
+	}
+
+	// Nine overlapping level k-1 nodes tile the middle of n.
+	parts := [9]*node{
+		n.nw, s.horizontal(n.nw, n.ne), n.ne,
+		s.vertical(n.nw, n.sw), s.centre(n), s.vertical(n.ne, n.se),
+		n.sw, s.horizontal(n.sw, n.se), n.se,
+	}
+
+	// First half: advance each part by 2^(k-3) when taking a full step;
+	// otherwise just take its centre and spend the whole 2^j in the second half.
+	var c [9]*node
+	for i, p := range parts {
+		if full {
+			c[i] = u.successor(p, n.level-3)
+		} else {
+			c[i] = s.centre(p)
+		}
+	}
+
+	second := j
+	if full {
+		second = n.level - 3
+	}
+	result := s.join(
+		u.successor(s.join(c[0], c[1], c[3], c[4]), second),
+		u.successor(s.join(c[1], c[2], c[4], c[5]), second),
+		u.successor(s.join(c[3], c[4], c[6], c[7]), second),
+		u.successor(s.join(c[4], c[5], c[7], c[8]), second),
+	)
+
+	if full {
+		n.next = result
+	} else {
+		s.steps[stepKey{n, j}] = result
+	}
+	return result
+}
+
+// base advances the centre 2x2 of a level-2 (4x4) node by one generation.
+func (u *Universe) base(n *node) *node {
+	var cells [4][4]bool
+	for qy, row := range [2][2]*node{{n.nw, n.ne}, {n.sw, n.se}} {
+		for qx, q := range row {
+			cells[qy*2][qx*2] = q.nw.alive
+			cells[qy*2][qx*2+1] = q.ne.alive
+			cells[qy*2+1][qx*2] = q.sw.alive
+			cells[qy*2+1][qx*2+1] = q.se.alive
+		}
+	}
+
+	next := func(x, y int) *node {
+		neighbors := 0
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if (dx != 0 || dy != 0) && cells[y+dy][x+dx] {
+					neighbors++
+				}
+			}
+		}
+		return u.store.leaf(u.rule.Next(cells[y][x], neighbors))
+	}
+	return u.store.join(next(1, 1), next(2, 1), next(1, 2), next(2, 2))
+}
+
+// Bounds returns the bounding box of the live cells, inclusive.
+func (u *Universe) Bounds() (minX, minY, maxX, maxY int64, ok bool) {
+	if u.root.population == 0 {
+		return 0, 0, 0, 0, false
+	}
+	minX, minY = u.originX+u.size(), u.originY+u.size()
+	maxX, maxY = u.originX-1, u.originY-1
+	var walk func(n *node, x, y int64)
+	walk = func(n *node, x, y int64) {
+		if n.population == 0 {
+			return
+		}
+		size := int64(1) << n.level
+		// Skip subtrees that cannot improve any edge of the box.
+		if x >= minX && x+size-1 <= maxX && y >= minY && y+size-1 <= maxY {
+			return
+		}
+		if n.level == 0 {
+			minX, maxX = min(minX, x), max(maxX, x)
+			minY, maxY = min(minY, y), max(maxY, y)
+			return
+		}
+		half := size / 2
+		walk(n.nw, x, y)
+		walk(n.ne, x+half, y)
+		walk(n.sw, x, y+half)
+		walk(n.se, x+half, y+half)
+	}
+	walk(u.root, u.originX, u.originY)
+	return minX, minY, maxX, maxY, true
+}
+
+// Pattern returns the live cells inside the bounding box as a pattern whose
+// top-left corner is (minX, minY) of Bounds.
+func (u *Universe) Pattern() *ddd.Pattern {
+	minX, minY, maxX, maxY, ok := u.Bounds()
+	if !ok {
+		return ddd.NewPattern(0, 0)
+	}
+	p := ddd.NewPattern(int(maxX-minX+1), int(maxY-minY+1))
+	u.each(func(x, y int64) {
+		p.Set(int(x-minX), int(y-minY), true)
+	})
+	p.Rule = u.rule.String()
+	return p
+}
+
+// WriteTo copies the live cells into b, shifted by (offsetX, offsetY). Cells
+// are placed according to b's topology.
+func (u *Universe) WriteTo(b ddd.GolBoard, offsetX, offsetY int64) {
+	u.each(func(x, y int64) {
+		b.SetCoordinate(int(x+offsetX), int(y+offsetY), true)
+	})
+}
+
+// each calls fn for every live cell.
+func (u *Universe) each(fn func(x, y int64)) {
+	var walk func(n *node, x, y int64)
+	walk = func(n *node, x, y int64) {
+		if n.population == 0 {
+			return
+		}
+		if n.level == 0 {
+			fn(x, y)
+			return
+		}
+		half := int64(1) << (n.level - 1)
+		walk(n.nw, x, y)
+		walk(n.ne, x+half, y)
+		walk(n.sw, x, y+half)
+		walk(n.se, x+half, y+half)
+	}
+	walk(u.root, u.originX, u.originY)
+}
@@ -0,0 +1,142 @@
+package hashlife
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	core "SideProjectGames/internal/ddd"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	gliderRLE      = "x = 3, y = 3\nbo$2bo$3o!"
+	rPentominoRLE  = "x = 3, y = 3\nb2o$2o$bo!"
+	replicatorRLE  = "x = 5, y = 5, rule = B36/S23\n2b3o$bo2bo$o3bo$o2bo$3o!"
+	naiveBoardSize = 192
+)
+
+func mustPattern(t *testing.T, rle string) *ddd.Pattern {
+	t.Helper()
+	p, err := ddd.ReadRLE(strings.NewReader(rle))
+	if err != nil {
+		t.Fatalf("Expected pattern to parse, but got %v", err)
+	}
+	return p
+}
+
+// naive places p in the middle of a bounded board large enough that the
+// pattern never reaches the edge, so it behaves like an unbounded universe.
+func naive(p *ddd.Pattern, rule ddd.Rule) (engine.Engine, int64) {
+	board := ddd.NewGOLBoard(naiveBoardSize, naiveBoardSize, core.Bounded)
+	scratch := ddd.NewGOLBoard(naiveBoardSize, naiveBoardSize, core.Bounded)
+	offset := int64(naiveBoardSize / 2)
+	p.Place(board, int(offset), int(offset))
+	return engine.NewBoardEngine(board, scratch, engine.NewSerialStepper(rule)), offset
+}
+
+// assertSameUniverse compares every cell of the naive board against u.
+func assertSameUniverse(t *testing.T, u *Universe, want engine.Engine, offset int64) {
+	t.Helper()
+	if u.Population() != want.Population() {
+		t.Fatalf("Generation %d: expected population %d, but got %d", u.Generation(), want.Population(), u.Population())
+	}
+	for y := int64(0); y < naiveBoardSize; y++ {
+		for x := int64(0); x < naiveBoardSize; x++ {
+			if got := u.Alive(x-offset, y-offset); got != want.Alive(x, y) {
+				t.Fatalf("Generation %d: cell (%d, %d) is %v, expected %v", u.Generation(), x-offset, y-offset, got, !got)
+			}
+		}
+	}
+
+	minX, minY, maxX, maxY, ok := u.Bounds()
+	wMinX, wMinY, wMaxX, wMaxY, wOk := want.Bounds()
+	if ok != wOk || minX != wMinX-offset || minY != wMinY-offset || maxX != wMaxX-offset || maxY != wMaxY-offset {
+		t.Fatalf("Generation %d: expected bounds (%d,%d)-(%d,%d), but got (%d,%d)-(%d,%d)",
+			u.Generation(), wMinX-offset, wMinY-offset, wMaxX-offset, wMaxY-offset, minX, minY, maxX, maxY)
+	}
+}
+
+func compareWithNaive(t *testing.T, rle string, rule ddd.Rule, steps []int64) {
+	t.Helper()
+	p := mustPattern(t, rle)
+	u, err := FromPattern(p, rule)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	want, offset := naive(p, rule)
+
+	for _, n := range steps {
+		u.Step(n)
+		want.Step(n)
+		assertSameUniverse(t, u, want, offset)
+	}
+}
+
+func TestUniverse_MatchesNaiveStepper_Glider(t *testing.T) {
+	compareWithNaive(t, gliderRLE, ddd.Conway, []int64{1, 1, 2, 4, 7, 16, 33})
+}
+
+func TestUniverse_MatchesNaiveStepper_RPentomino(t *testing.T) {
+	compareWithNaive(t, rPentominoRLE, ddd.Conway, []int64{1, 5, 10, 32, 64, 17})
+}
+
+func TestUniverse_MatchesNaiveStepper_HighLifeReplicator(t *testing.T) {
+	highLife, err := ddd.ParseRule("B36/S23")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	compareWithNaive(t, replicatorRLE, highLife, []int64{3, 12, 24, 48})
+}
+
+func TestUniverse_AdvanceByPowerOfTwo(t *testing.T) {
+	u, err := FromPattern(mustPattern(t, gliderRLE), ddd.Conway)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	// A glider moves one cell diagonally every 4 generations.
+	u.Advance(20)
+	if u.Generation() != 1<<20 {
+		t.Errorf("Expected generation %d, but got %d", 1<<20, u.Generation())
+	}
+	if u.Population() != 5 {
+		t.Errorf("Expected the glider to keep 5 cells, but got %d", u.Population())
+	}
+	minX, minY, _, _, _ := u.Bounds()
+	shift := int64(1<<20) / 4
+	if minX != shift || minY != shift {
+		t.Errorf("Expected the glider at (%d, %d), but got (%d, %d)", shift, shift, minX, minY)
+	}
+}
+
+func TestUniverse_FromBoardKeepsCoordinates(t *testing.T) {
+	board := ddd.NewGOLBoard(20, 20, core.Toroidal)
+	mustPattern(t, gliderRLE).Place(board, 7, 9)
+
+	u, err := FromBoard(board, ddd.Conway)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !u.Alive(8, 9) || !u.Alive(9, 10) || !u.Alive(7, 11) || u.Alive(7, 9) {
+		t.Error("Expected the board's live cells at the same coordinates")
+	}
+
+	out := ddd.NewGOLBoard(20, 20, core.Toroidal)
+	u.WriteTo(out, 0, 0)
+	for i, alive := range board.FlatSlice() {
+		if out.FlatSlice()[i] != alive {
+			t.Fatalf("Expected WriteTo to reproduce the board, cell %d differs", i)
+		}
+	}
+}
+
+func TestNew_RejectsBirthOnZero(t *testing.T) {
+	rule, err := ddd.ParseRule("B0123/S")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if _, err := New(rule); !errors.Is(err, engine.ErrBirthOnZero) {
+		t.Errorf("Expected ErrBirthOnZero, but got %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+// Package history keeps a bounded record of past generations so a run can be
+// rewound and scrubbed. Most generations are stored as the cells that
+// changed since the one before, with a full keyframe every so often, so a
+// long history of a mostly still board costs little. It is free of Ebiten so
+// it can be unit tested.
+package history
+
+import (
+	"SideProjectGames/gameoflife/internal/engine"
+	"cmp"
+	"slices"
+)
+
+// Defaults for New. DefaultMaxCells is 64 MB of stored cells.
+const (
+	DefaultCapacity      = 1000
+	DefaultKeyframeEvery = 32
+	DefaultMaxCells      = 1 << 22
+)
+
+// History is the record of a run: a window of consecutive generations from
+// Oldest to Newest.
+type History interface {
+	// Record stores the live cells of generation gen. Recording a generation
+	// at or before Newest branches: the generations from gen on are
+	// forgotten first. Recording after a gap starts the history over. The
+	// history keeps cells, so the caller must not change them afterwards.
+	Record(gen int64, cells []engine.Cell)
+	// At returns the live cells of a stored generation, in reading order.
+	At(gen int64) ([]engine.Cell, bool)
+	// Truncate forgets every generation after gen.
+	Truncate(gen int64)
+	Reset()
+	Len() int
+	// Oldest and Newest are the first and last stored generations; they are
+	// meaningless when Len is 0.
+	Oldest() int64
+	Newest() int64
+}
+
+// entry is one generation: either all of its live cells (a keyframe) or the
+// cells that changed since the generation before.
+type entry struct {
+	keyframe bool
+	cells    []engine.Cell
+}
+
+type history struct {
+	capacity      int
+	keyframeEvery int
+	maxCells      int
+	oldest        int64
+	entries       []entry
+	// stored is the number of cells in entries.
+	stored int
+	// last is the newest generation in full, kept to diff the next one.
+	last []engine.Cell
+}
+
+var _ History = (*history)(nil)
+
+// New returns an empty history of at most capacity generations with a
+// keyframe at least every keyframeEvery generations. Once its keyframes and
+// diffs hold more than maxCells cells, the oldest generations are forgotten
+// too; the newest one is always kept. Non-positive values use the defaults.
+func New(capacity, keyframeEvery, maxCells int) History {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	if keyframeEvery <= 0 {
+		keyframeEvery = DefaultKeyframeEvery
+	}
+	if maxCells <= 0 {
+		maxCells = DefaultMaxCells
+	}
+	return &history{capacity: capacity, keyframeEvery: keyframeEvery, maxCells: maxCells}
+}
+
+func (h *history) Record(gen int64, cells []engine.Cell) {
+	cells = sorted(cells)
+	switch {
+	case len(h.entries) == 0, gen <= h.oldest, gen > h.Newest()+1:
+		h.Reset()
+		h.oldest = gen
+	case gen <= h.Newest():
+		h.Truncate(gen - 1)
+	}
+
+	if len(h.entries)%h.keyframeEvery == 0 {
+		h.push(entry{keyframe: true, cells: cells})
+	} else {
+		h.push(entry{cells: toggle(h.last, cells)})
+	}
+	h.last = cells
+
+	for len(h.entries) > h.capacity || h.stored > h.maxCells {
+		if !h.dropOldest() {
+			// A single keyframe and its diffs are over the budget; start
+			// again from the newest generation alone.
+			h.restart()
+			break
+		}
+	}
+}
+
+func (h *history) push(e entry) {
+	h.entries = append(h.entries, e)
+	h.stored += len(e.cells)
+}
+
+// dropOldest forgets the oldest keyframe and its diffs, so the history
+// still starts on a keyframe. It reports false, changing nothing, when that
+// would forget every generation.
+func (h *history) dropOldest() bool {
+	n := 1
+	for n < len(h.entries) && !h.entries[n].keyframe {
+		n++
+	}
+	if n == len(h.entries) {
+		return false
+	}
+	for _, e := range h.entries[:n] {
+		h.stored -= len(e.cells)
+	}
+	h.entries = slices.Delete(h.entries, 0, n)
+	h.oldest += int64(n)
+	return true
+}
+
+// restart keeps only the newest generation, as a keyframe.
+func (h *history) restart() {
+	newest := h.Newest()
+	h.entries, h.stored = nil, 0
+	h.oldest = newest
+	h.push(entry{keyframe: true, cells: h.last})
+}
+
+func (h *history) At(gen int64) ([]engine.Cell, bool) {
+	if len(h.entries) == 0 || gen < h.oldest || gen > h.Newest() {
+		return nil, false
+	}
+	if gen == h.Newest() {
+		return slices.Clone(h.last), true
+	}
+	i := int(gen - h.oldest)
+	k := i
+	for !h.entries[k].keyframe {
+		k--
+	}
+	cells := slices.Clone(h.entries[k].cells)
+	for _, e := range h.entries[k+1 : i+1] {
+		cells = toggle(cells, e.cells)
+	}
+	return cells, true
+}
+
+func (h *history) Truncate(gen int64) {
+	if len(h.entries) == 0 || gen >= h.Newest() {
+		return
+	}
+	if gen < h.oldest {
+		h.Reset()
+		return
+	}
+	h.last, _ = h.At(gen)
+	for _, e := range h.entries[gen-h.oldest+1:] {
+		h.stored -= len(e.cells)
+	}
+	h.entries = h.entries[:gen-h.oldest+1]
+}
+
+func (h *history) Reset() {
+	h.entries, h.last, h.oldest, h.stored = nil, nil, 0, 0
+}
+
+func (h *history) Len() int {
+	return len(h.entries)
+}
+
+func (h *history) Oldest() int64 {
+	return h.oldest
+}
+
+func (h *history) Newest() int64 {
+	return h.oldest + int64(len(h.entries)) - 1
+}
+
+func compareCells(a, b engine.Cell) int {
+	return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+}
+
+// sorted returns cells in reading order, copying them only if they are not.
+func sorted(cells []engine.Cell) []engine.Cell {
+	if slices.IsSortedFunc(cells, compareCells) {
+		return cells
+	}
+	cells = slices.Clone(cells)
+	slices.SortFunc(cells, compareCells)
+	return cells
+}
+
+// toggle returns the cells in exactly one of a and b, both in reading order:
+// a generation with a diff applied, or the diff between two generations.
+func toggle(a, b []engine.Cell) []engine.Cell {
+	out := make([]engine.Cell, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := compareCells(a[i], b[j]); {
+		case c < 0:
+			out = append(out, a[i])
+			i++
+		case c > 0:
+			out = append(out, b[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return append(out, b[j:]...)
+}
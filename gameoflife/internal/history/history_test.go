@@ -0,0 +1,145 @@
+package history
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"slices"
+	"testing"
+)
+
+// run returns gens generations of an R-pentomino, which changes a lot every
+// generation.
+func run(gens int) [][]engine.Cell {
+	b := ddd.NewSparseBoard()
+	for _, c := range [][2]int{{1, 0}, {2, 0}, {0, 1}, {1, 1}, {1, 2}} {
+		b.SetCoordinate(c[0], c[1], true)
+	}
+	e := engine.NewSparseEngine(b, ddd.Conway)
+	out := make([][]engine.Cell, gens)
+	for i := range out {
+		out[i] = sorted(engine.SparseLiveCells(e.Board()))
+		e.Step(1)
+	}
+	return out
+}
+
+func TestHistory_RecallsEveryGeneration(t *testing.T) {
+	gens := run(100)
+	h := New(0, 8, 0)
+	for i, cells := range gens {
+		h.Record(int64(i), cells)
+	}
+	if h.Len() != 100 || h.Oldest() != 0 || h.Newest() != 99 {
+		t.Fatalf("Expected generations 0 to 99, but got %d from %d to %d", h.Len(), h.Oldest(), h.Newest())
+	}
+	for i, want := range gens {
+		got, ok := h.At(int64(i))
+		if !ok || !slices.Equal(got, want) {
+			t.Fatalf("Expected generation %d to come back as recorded", i)
+		}
+	}
+	if _, ok := h.At(100); ok {
+		t.Error("Expected no generation 100")
+	}
+}
+
+func TestHistory_StoresDiffs(t *testing.T) {
+	h := New(0, 4, 0).(*history)
+	block := []engine.Cell{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+	for gen := range int64(8) {
+		h.Record(gen, block)
+	}
+	for i, e := range h.entries {
+		if e.keyframe != (i%4 == 0) {
+			t.Errorf("Expected a keyframe every 4 generations, but entry %d has keyframe %v", i, e.keyframe)
+		}
+		if !e.keyframe && len(e.cells) != 0 {
+			t.Errorf("Expected an empty diff for a still life, but entry %d has %v", i, e.cells)
+		}
+	}
+}
+
+func TestHistory_IsBounded(t *testing.T) {
+	gens := run(50)
+	h := New(20, 5, 0)
+	for i, cells := range gens {
+		h.Record(int64(i), cells)
+	}
+	if h.Len() > 20 || h.Newest() != 49 {
+		t.Fatalf("Expected at most 20 generations ending at 49, but got %d ending at %d", h.Len(), h.Newest())
+	}
+	if _, ok := h.At(h.Oldest() - 1); ok {
+		t.Error("Expected the oldest generations to be forgotten")
+	}
+	got, ok := h.At(h.Oldest())
+	if !ok || !slices.Equal(got, gens[h.Oldest()]) {
+		t.Errorf("Expected generation %d to survive the trimming", h.Oldest())
+	}
+}
+
+func TestHistory_KeepsToItsCellBudget(t *testing.T) {
+	gens := run(200)
+	h := New(0, 5, 1000).(*history)
+	for i, cells := range gens {
+		h.Record(int64(i), cells)
+		if h.stored > 1000 && h.Len() > 1 {
+			t.Fatalf("Expected at most 1000 stored cells at generation %d, but got %d", i, h.stored)
+		}
+	}
+	if h.Newest() != 199 || h.Len() >= 200 {
+		t.Fatalf("Expected the oldest generations dropped for space, but got %d ending at %d", h.Len(), h.Newest())
+	}
+	for gen := h.Oldest(); gen <= h.Newest(); gen++ {
+		if got, ok := h.At(gen); !ok || !slices.Equal(got, gens[gen]) {
+			t.Fatalf("Expected generation %d to survive the trimming", gen)
+		}
+	}
+
+	h = New(0, 5, 1).(*history)
+	for i, cells := range gens[:20] {
+		h.Record(int64(i), cells)
+	}
+	if got, ok := h.At(19); h.Len() != 1 || !ok || !slices.Equal(got, gens[19]) {
+		t.Errorf("Expected only the newest generation kept under a tiny budget, but got %d generations", h.Len())
+	}
+}
+
+func TestHistory_Branches(t *testing.T) {
+	gens := run(30)
+	h := New(0, 8, 0)
+	for i, cells := range gens {
+		h.Record(int64(i), cells)
+	}
+
+	// Rewind to generation 10 and carry on differently.
+	other := []engine.Cell{{X: 5, Y: 5}}
+	h.Record(11, other)
+	if h.Newest() != 11 {
+		t.Fatalf("Expected the branch to end at generation 11, but got %d", h.Newest())
+	}
+	if got, _ := h.At(11); !slices.Equal(got, other) {
+		t.Errorf("Expected generation 11 to be the new branch, but got %v", got)
+	}
+	if got, _ := h.At(10); !slices.Equal(got, gens[10]) {
+		t.Error("Expected generation 10 to be kept")
+	}
+
+	h.Truncate(3)
+	if h.Newest() != 3 {
+		t.Errorf("Expected Truncate to keep generations up to 3, but got %d", h.Newest())
+	}
+	h.Record(4, gens[4])
+	if got, _ := h.At(4); !slices.Equal(got, gens[4]) {
+		t.Error("Expected recording to carry on after Truncate")
+	}
+
+	h.Record(0, other)
+	if h.Len() != 1 || h.Oldest() != 0 {
+		t.Errorf("Expected recording generation 0 again to start over, but got %d from %d", h.Len(), h.Oldest())
+	}
+
+	h.Record(40, other)
+	if h.Len() != 1 || h.Oldest() != 40 {
+		t.Errorf("Expected a gap to start the history over, but got %d from %d", h.Len(), h.Oldest())
+	}
+}
@@ -0,0 +1,122 @@
+// Package library is the catalog of classic Game of Life patterns built into
+// the binary: still lifes, oscillators, spaceships, guns and methuselahs. The
+// patterns are RLE files embedded from the patterns directory, one
+// subdirectory per category.
+package library
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+//go:embed patterns
+var patterns embed.FS
+
+// Category groups the patterns by how they behave.
+type Category string
+
+const (
+	StillLife  Category = "Still Life"
+	Oscillator Category = "Oscillator"
+	Spaceship  Category = "Spaceship"
+	Gun        Category = "Gun"
+	Methuselah Category = "Methuselah"
+)
+
+// Categories lists every category in catalog order.
+var Categories = []Category{StillLife, Oscillator, Spaceship, Gun, Methuselah}
+
+// categoryDirs maps each category to its directory under patterns.
+var categoryDirs = map[Category]string{
+	StillLife:  "stilllife",
+	Oscillator: "oscillator",
+	Spaceship:  "spaceship",
+	Gun:        "gun",
+	Methuselah: "methuselah",
+}
+
+// Entry is one pattern of the catalog.
+type Entry struct {
+	Category Category
+	Pattern  *ddd.Pattern
+}
+
+// Name is the pattern's #N name.
+func (e Entry) Name() string {
+	return e.Pattern.Name
+}
+
+// Catalog is a read-only, ordered list of patterns.
+type Catalog interface {
+	// Len is the number of patterns.
+	Len() int
+	// At returns the i-th pattern, in category order and by file name
+	// within a category.
+	At(i int) Entry
+	// Find looks a pattern up by name, ignoring case.
+	Find(name string) (Entry, bool)
+}
+
+type catalog struct {
+	entries []Entry
+}
+
+var _ Catalog = (*catalog)(nil)
+
+// Load parses the embedded patterns.
+func Load() (Catalog, error) {
+	return load(patterns)
+}
+
+func load(fsys fs.FS) (*catalog, error) {
+	c := &catalog{}
+	for _, category := range Categories {
+		dir := path.Join("patterns", categoryDirs[category])
+		files, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			return nil, err
+		}
+		// ReadDir returns the files sorted by name.
+		for _, file := range files {
+			if file.IsDir() || path.Ext(file.Name()) != ".rle" {
+				continue
+			}
+			name := path.Join(dir, file.Name())
+			f, err := fsys.Open(name)
+			if err != nil {
+				return nil, err
+			}
+			p, err := ddd.ReadRLE(f)
+			f.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if p.Name == "" {
+				p.Name = strings.TrimSuffix(file.Name(), ".rle")
+			}
+			c.entries = append(c.entries, Entry{Category: category, Pattern: p})
+		}
+	}
+	return c, nil
+}
+
+func (c *catalog) Len() int {
+	return len(c.entries)
+}
+
+func (c *catalog) At(i int) Entry {
+	return c.entries[i]
+}
+
+func (c *catalog) Find(name string) (Entry, bool) {
+	for _, e := range c.entries {
+		if strings.EqualFold(e.Name(), name) {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
@@ -0,0 +1,129 @@
+package library
+
+import (
+	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"maps"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// shape returns the live cells of b relative to the top-left of their
+// bounding box, so translated copies of a pattern compare equal.
+func shape(b ddd.SparseBoard) (cells map[[2]int]bool, x, y int) {
+	x, y, _, _, _ = b.Bounds()
+	cells = make(map[[2]int]bool)
+	b.EachLive(func(cx, cy int) { cells[[2]int{cx - x, cy - y}] = true })
+	return cells, x, y
+}
+
+// run places e at the origin and returns the board after generations steps.
+func run(e Entry, generations int) ddd.SparseBoard {
+	current, next := ddd.NewSparseBoard(), ddd.NewSparseBoard()
+	e.Pattern.Place(current, 0, 0)
+	stepper := engine.NewSparseStepper(ddd.Conway)
+	for range generations {
+		stepper.Step(current, next)
+		current, next = next, current
+	}
+	return current
+}
+
+func TestLoad_EveryCategoryHasPatterns(t *testing.T) {
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	counts := make(map[Category]int)
+	names := make(map[string]bool)
+	for i := range c.Len() {
+		e := c.At(i)
+		counts[e.Category]++
+		if names[e.Name()] {
+			t.Errorf("Expected unique names, but %q appears twice", e.Name())
+		}
+		names[e.Name()] = true
+		if e.Pattern.Population() == 0 {
+			t.Errorf("Expected %q to have live cells", e.Name())
+		}
+	}
+	for _, category := range Categories {
+		if counts[category] == 0 {
+			t.Errorf("Expected at least one %s, but got none", category)
+		}
+	}
+}
+
+func TestLoad_PatternsBehaveAsAdvertised(t *testing.T) {
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		period int
+		moves  bool
+	}{
+		{"Block", 1, false},
+		{"Beehive", 1, false},
+		{"Loaf", 1, false},
+		{"Boat", 1, false},
+		{"Tub", 1, false},
+		{"Blinker", 2, false},
+		{"Toad", 2, false},
+		{"Beacon", 2, false},
+		{"Pulsar", 3, false},
+		{"Pentadecathlon", 15, false},
+		{"Glider", 4, true},
+		{"Lightweight spaceship", 4, true},
+		{"Middleweight spaceship", 4, true},
+		{"Heavyweight spaceship", 4, true},
+	}
+	for _, tt := range tests {
+		e, ok := c.Find(tt.name)
+		if !ok {
+			t.Errorf("Expected %q in the catalog", tt.name)
+			continue
+		}
+		start, x0, y0 := shape(run(e, 0))
+		for gen := 1; gen < tt.period; gen++ {
+			if got, _, _ := shape(run(e, gen)); maps.Equal(got, start) {
+				t.Errorf("Expected %s to have period %d, but it repeats after %d", tt.name, tt.period, gen)
+			}
+		}
+		got, x1, y1 := shape(run(e, tt.period))
+		if !maps.Equal(got, start) {
+			t.Errorf("Expected %s to repeat after %d generations", tt.name, tt.period)
+		}
+		if moved := x0 != x1 || y0 != y1; moved != tt.moves {
+			t.Errorf("Expected %s to move: %v, but got %v", tt.name, tt.moves, moved)
+		}
+	}
+}
+
+func TestLoad_Diehard(t *testing.T) {
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	e, ok := c.Find("diehard")
+	if !ok {
+		t.Fatal("Expected Find to ignore case")
+	}
+	if got := run(e, 129).Population(); got == 0 {
+		t.Error("Expected Diehard to be alive after 129 generations")
+	}
+	if got := run(e, 130).Population(); got != 0 {
+		t.Errorf("Expected Diehard to vanish after 130 generations, but got %d cells", got)
+	}
+}
+
+func TestLoad_ReportsBadFiles(t *testing.T) {
+	fsys := fstest.MapFS{"patterns/stilllife/broken.rle": {Data: []byte("2o$2o!")}}
+	if _, err := load(fsys); err == nil || !strings.Contains(err.Error(), "broken.rle") {
+		t.Errorf("Expected an error naming the RLE file without a header, but got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package multistate
+
+import (
+	gol "SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+)
+
+// Board composes the generic ddd.Board with the helpers a multi-state rule
+// needs.
+type Board interface {
+	ddd.Board[uint8]
+	// CountFiring counts the Moore neighbours of (x, y) in state 1.
+	CountFiring(x int, y int) int
+	// SeedBoard replaces the board with a random soup of state 1 cells, laid
+	// out like a Game of Life soup. Cells outside the seeded region are empty.
+	SeedBoard(r rng.Source, opts gol.SeedOptions)
+}
+
+type board struct {
+	ddd.Board[uint8]
+}
+
+var _ Board = (*board)(nil)
+
+// NewBoard creates an empty board whose edges follow topology. On a Bounded
+// board everything past the edge is empty.
+func NewBoard(width int, height int, topology ddd.Topology) Board {
+	return newBoard(width, height, topology)
+}
+
+func newBoard(width int, height int, topology ddd.Topology) *board {
+	return &board{Board: ddd.NewBoard[uint8](width, height, ddd.WithTopology[uint8](topology))}
+}
+
+func (b *board) CountFiring(x int, y int) int {
+	n := 0
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if (dx != 0 || dy != 0) && b.Coordinate(x+dx, y+dy) == 1 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func (b *board) SeedBoard(r rng.Source, opts gol.SeedOptions) {
+	b.CopyBoard(make([]uint8, b.Cols()*b.Rows()))
+	if opts.Width <= 0 || opts.Height <= 0 {
+		opts.X, opts.Y, opts.Width, opts.Height = 0, 0, b.Cols(), b.Rows()
+	}
+	gol.Seed(firing{b}, r, opts)
+}
+
+// firing is a two-state view of a board: a cell is alive when it is in
+// state 1, and setting it alive puts it in state 1.
+type firing struct {
+	b ddd.Board[uint8]
+}
+
+var _ ddd.Grid[bool] = firing{}
+
+func (f firing) Coordinate(x int, y int) bool {
+	return f.b.Coordinate(x, y) == 1
+}
+
+func (f firing) SetCoordinate(x int, y int, alive bool) error {
+	var state uint8
+	if alive {
+		state = 1
+	}
+	return f.b.SetCoordinate(x, y, state)
+}
@@ -0,0 +1,55 @@
+package multistate
+
+import "image/color"
+
+// Palette is the colour of each state, indexed by state.
+type Palette []color.RGBA
+
+var wireworldPalette = Palette{
+	Empty:     {A: 255},
+	Head:      {R: 60, G: 140, B: 255, A: 255},
+	Tail:      {R: 255, G: 70, B: 40, A: 255},
+	Conductor: {R: 230, G: 180, B: 40, A: 255},
+}
+
+// DefaultPalette returns the palette rule is drawn with. Wireworld uses the
+// customary blue heads, red tails and copper conductors on black. Generations
+// rules draw live cells white and fade dying cells from yellow to dark red.
+func DefaultPalette(rule Rule) Palette {
+	if _, ok := rule.(Wireworld); ok {
+		return append(Palette(nil), wireworldPalette...)
+	}
+
+	p := make(Palette, rule.States())
+	p[0] = color.RGBA{A: 255}
+	if len(p) > 1 {
+		p[1] = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	}
+	dying := len(p) - 2
+	for i := 0; i < dying; i++ {
+		// t runs from 0 for the youngest dying state to 1 for the oldest.
+		t := 0.0
+		if dying > 1 {
+			t = float64(i) / float64(dying-1)
+		}
+		p[i+2] = color.RGBA{
+			R: uint8(255 - 135*t),
+			G: uint8(220 * (1 - t)),
+			B: uint8(40 * (1 - t)),
+			A: 255,
+		}
+	}
+	return p
+}
+
+// Color returns the colour of state, or the empty state's colour for a state
+// the palette has no entry for.
+func (p Palette) Color(state uint8) color.RGBA {
+	if int(state) < len(p) {
+		return p[state]
+	}
+	if len(p) > 0 {
+		return p[0]
+	}
+	return color.RGBA{A: 255}
+}
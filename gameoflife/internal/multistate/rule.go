@@ -0,0 +1,348 @@
+// Package multistate runs cellular automata whose cells have more than two
+// states, such as Generations rules (Brian's Brain), Larger-than-Life rules
+// with dying states and Wireworld, on a ddd.Board[uint8]. State 0 is always
+// the empty state.
+package multistate
+
+import (
+	gol "SideProjectGames/gameoflife/internal/ddd"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxStates is the most states a cell can have, as one state fits in a byte.
+const MaxStates = 256
+
+// Rule decides the next state of a cell from its own state and how many of
+// its neighbours are in state 1, which is the state that spreads: the live
+// state of a Generations rule and the electron head of Wireworld. The
+// neighbours are the 8 nearest cells, except for LargerThanLife.
+type Rule interface {
+	// States is how many states a cell can be in, 0 to States()-1.
+	States() int
+	Next(state uint8, firing int) uint8
+	// StateName describes a state for the HUD, such as "Electron head".
+	StateName(state uint8) string
+	String() string
+}
+
+// Generations is a Life-like rule with extra dying states: a live cell that
+// does not survive ages through states 2 to States()-1, one step per
+// generation, before it is empty again. Only live cells count as neighbours
+// and dying cells block births.
+type Generations struct {
+	birth   uint16
+	survive uint16
+	states  int
+}
+
+// LargerThanLife is a Larger-than-Life rule whose cells have dying states,
+// which behave as in Generations rules. Its neighbours are counted over the
+// rule's own neighbourhood rather than the 8 nearest cells.
+type LargerThanLife struct {
+	rule gol.LtLRule
+}
+
+// Wireworld simulates electronics: electrons (a head followed by a tail) run
+// along conductors, and a conductor cell becomes a head when one or two of
+// its neighbours are heads.
+type Wireworld struct{}
+
+// Wireworld states.
+const (
+	Empty     uint8 = 0
+	Head      uint8 = 1
+	Tail      uint8 = 2
+	Conductor uint8 = 3
+)
+
+var (
+	_ Rule = Generations{}
+	_ Rule = LargerThanLife{}
+	_ Rule = Wireworld{}
+)
+
+// namedRules maps well-known rule names to their B/S/C strings, or to
+// WIREWORLD.
+var namedRules = map[string]string{
+	"WIREWORLD":   "WIREWORLD",
+	"BRIANSBRAIN": "B2/S/C3",
+	"BRAIN":       "B2/S/C3",
+	"STARWARS":    "B2/S345/C4",
+	"FROGS":       "B34/S12/C3",
+	"STICKS":      "B2/S3456/C6",
+	"FIREWORKS":   "B13/S2/C21",
+}
+
+// NewGenerations builds a Generations rule from neighbour counts (0-8) that
+// cause a birth and survival, and the number of states (2 to MaxStates). Two
+// states is an ordinary Life-like rule.
+func NewGenerations(birth []int, survive []int, states int) (Generations, error) {
+	var r Generations
+	for _, n := range birth {
+		if n < 0 || n > 8 {
+			return Generations{}, fmt.Errorf("birth count %d out of range 0-8", n)
+		}
+		r.birth |= 1 << n
+	}
+	for _, n := range survive {
+		if n < 0 || n > 8 {
+			return Generations{}, fmt.Errorf("survival count %d out of range 0-8", n)
+		}
+		r.survive |= 1 << n
+	}
+	if states < 2 || states > MaxStates {
+		return Generations{}, fmt.Errorf("state count %d out of range 2-%d", states, MaxStates)
+	}
+	r.states = states
+	return r, nil
+}
+
+// NewLargerThanLife wraps a Larger-than-Life rule so that its dying states,
+// if it has any, can be run.
+func NewLargerThanLife(rule gol.LtLRule) LargerThanLife {
+	return LargerThanLife{rule: rule}
+}
+
+// ParseRule reads WireWorld, a Generations rule in B/S/C notation
+// ("B2/S/C3", case-insensitive, any order) or the survival-first S/B/C
+// notation ("/2/3"), a rule in Larger-than-Life notation (see
+// gol.ParseLtL), or one of the well-known names such as "Brian's Brain" or
+// "Star Wars".
+func ParseRule(s string) (Rule, error) {
+	if gol.IsLtL(s) {
+		r, err := gol.ParseLtL(s)
+		if err != nil {
+			return nil, err
+		}
+		return NewLargerThanLife(r), nil
+	}
+	key := strings.NewReplacer(" ", "", "'", "", "_", "", "-", "").Replace(strings.ToUpper(strings.TrimSpace(s)))
+	if named, ok := namedRules[key]; ok {
+		key = named
+	}
+	if key == "WIREWORLD" {
+		return Wireworld{}, nil
+	}
+	if strings.ContainsAny(key, "BSC") {
+		return parseBSC(key, s)
+	}
+	return parseSBC(key, s)
+}
+
+// IsMultiState reports whether s names a rule ParseRule accepts with more
+// than two states, as opposed to an ordinary Life-like rule.
+func IsMultiState(s string) bool {
+	r, err := ParseRule(s)
+	return err == nil && r.States() > 2
+}
+
+// parseBSC handles "B2/S/C3" with its sections in any order. A missing C
+// section means two states.
+func parseBSC(upper string, original string) (Rule, error) {
+	var birth, survive []int
+	states := 2
+	seen := make(map[byte]bool)
+	for _, part := range strings.Split(upper, "/") {
+		if part == "" {
+			return nil, fmt.Errorf("rule %q: empty section", original)
+		}
+		section := part[0]
+		if seen[section] {
+			return nil, fmt.Errorf("rule %q: %c given twice", original, section)
+		}
+		seen[section] = true
+		switch section {
+		case 'B', 'S':
+			counts, err := parseCounts(part[1:], original)
+			if err != nil {
+				return nil, err
+			}
+			if section == 'B' {
+				birth = counts
+			} else {
+				survive = counts
+			}
+		case 'C', 'G':
+			n, err := strconv.Atoi(part[1:])
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: bad state count %q", original, part[1:])
+			}
+			states = n
+		default:
+			return nil, fmt.Errorf("rule %q: unexpected section %q", original, part)
+		}
+	}
+	if !seen['B'] || !seen['S'] {
+		return nil, fmt.Errorf("rule %q: expected both a B and an S section", original)
+	}
+	r, err := NewGenerations(birth, survive, states)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", original, err)
+	}
+	return r, nil
+}
+
+// parseSBC handles the survival-first "345/2/4" notation.
+func parseSBC(upper string, original string) (Rule, error) {
+	parts := strings.Split(upper, "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("rule %q: expected WireWorld, B/S/C (B2/S/C3) or S/B/C (/2/3) notation", original)
+	}
+	survive, err := parseCounts(parts[0], original)
+	if err != nil {
+		return nil, err
+	}
+	birth, err := parseCounts(parts[1], original)
+	if err != nil {
+		return nil, err
+	}
+	states, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: bad state count %q", original, parts[2])
+	}
+	r, err := NewGenerations(birth, survive, states)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", original, err)
+	}
+	return r, nil
+}
+
+// parseCounts reads a run of neighbour counts such as "345".
+func parseCounts(digits string, original string) ([]int, error) {
+	counts := make([]int, 0, len(digits))
+	for _, ch := range digits {
+		if ch < '0' || ch > '8' {
+			return nil, fmt.Errorf("rule %q: unexpected character %q", original, ch)
+		}
+		counts = append(counts, int(ch-'0'))
+	}
+	return counts, nil
+}
+
+func (r Generations) States() int {
+	return r.states
+}
+
+func (r Generations) Next(state uint8, firing int) uint8 {
+	switch {
+	case state == 0:
+		if r.birth&(1<<firing) != 0 {
+			return 1
+		}
+		return 0
+	case state == 1 && r.survive&(1<<firing) != 0:
+		return 1
+	case int(state)+1 >= r.states:
+		return 0
+	}
+	return state + 1
+}
+
+func (r Generations) StateName(state uint8) string {
+	return generationsStateName(state, r.states)
+}
+
+// generationsStateName names the states of a rule with dying states.
+func generationsStateName(state uint8, states int) string {
+	switch {
+	case state == 0:
+		return "Dead"
+	case state == 1:
+		return "Alive"
+	case int(state) < states:
+		return fmt.Sprintf("Dying %d", int(state)-1)
+	}
+	return fmt.Sprintf("State %d", state)
+}
+
+// String returns the canonical B/S/C notation, e.g. "B2/S/C3".
+func (r Generations) String() string {
+	var sb strings.Builder
+	sb.WriteByte('B')
+	for n := 0; n <= 8; n++ {
+		if r.birth&(1<<n) != 0 {
+			sb.WriteByte(byte('0' + n))
+		}
+	}
+	sb.WriteString("/S")
+	for n := 0; n <= 8; n++ {
+		if r.survive&(1<<n) != 0 {
+			sb.WriteByte(byte('0' + n))
+		}
+	}
+	fmt.Fprintf(&sb, "/C%d", r.states)
+	return sb.String()
+}
+
+// LtL is the underlying Larger-than-Life rule.
+func (r LargerThanLife) LtL() gol.LtLRule {
+	return r.rule
+}
+
+func (r LargerThanLife) States() int {
+	return r.rule.States()
+}
+
+// Next takes firing as the weighted count of live cells in the rule's
+// neighbourhood, the cell itself left out.
+func (r LargerThanLife) Next(state uint8, firing int) uint8 {
+	switch {
+	case state == 0:
+		if r.rule.Next(false, firing) {
+			return 1
+		}
+		return 0
+	case state == 1 && r.rule.Next(true, firing):
+		return 1
+	case int(state)+1 >= r.rule.States():
+		return 0
+	}
+	return state + 1
+}
+
+func (r LargerThanLife) StateName(state uint8) string {
+	return generationsStateName(state, r.rule.States())
+}
+
+func (r LargerThanLife) String() string {
+	return r.rule.String()
+}
+
+func (Wireworld) States() int {
+	return 4
+}
+
+func (Wireworld) Next(state uint8, firing int) uint8 {
+	switch state {
+	case Head:
+		return Tail
+	case Tail:
+		return Conductor
+	case Conductor:
+		if firing == 1 || firing == 2 {
+			return Head
+		}
+		return Conductor
+	}
+	return Empty
+}
+
+var wireworldNames = [...]string{
+	Empty:     "Empty",
+	Head:      "Electron head",
+	Tail:      "Electron tail",
+	Conductor: "Conductor",
+}
+
+func (Wireworld) StateName(state uint8) string {
+	if int(state) < len(wireworldNames) {
+		return wireworldNames[state]
+	}
+	return fmt.Sprintf("State %d", state)
+}
+
+func (Wireworld) String() string {
+	return "WireWorld"
+}
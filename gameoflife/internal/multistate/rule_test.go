@@ -0,0 +1,117 @@
+package multistate
+
+import "testing"
+
+func TestParseRule_Notations(t *testing.T) {
+	cases := map[string]string{
+		"B2/S/C3":        "B2/S/C3",
+		"b2/s/c3":        "B2/S/C3",
+		"C3/S/B2":        "B2/S/C3",
+		"/2/3":           "B2/S/C3",
+		"345/2/4":        "B2/S345/C4",
+		"Brian's Brain":  "B2/S/C3",
+		"star wars":      "B2/S345/C4",
+		"B3/S23":         "B3/S23/C2",
+		"WireWorld":      "WireWorld",
+		"wire_world":     "WireWorld",
+		"B13/S2/C21":     "B13/S2/C21",
+		"B2/S/G3":        "B2/S/C3",
+		"B2/S/C256":      "B2/S/C256",
+		"  B2 / S / C3 ": "B2/S/C3",
+	}
+	for in, want := range cases {
+		r, err := ParseRule(in)
+		if err != nil {
+			t.Errorf("ParseRule(%q) returned error %v", in, err)
+			continue
+		}
+		if r.String() != want {
+			t.Errorf("ParseRule(%q) = %s; expected %s", in, r, want)
+		}
+	}
+}
+
+func TestParseRule_Invalid(t *testing.T) {
+	for _, in := range []string{"", "B2/S/C1", "B2/S/C257", "B9/S/C3", "B2/C3", "B2/S/C3/C4", "B2/S/Cx", "2/3", "hello"} {
+		if _, err := ParseRule(in); err == nil {
+			t.Errorf("Expected ParseRule(%q) to fail, but it succeeded", in)
+		}
+	}
+}
+
+func TestIsMultiState(t *testing.T) {
+	for in, want := range map[string]bool{
+		"WireWorld": true,
+		"B2/S/C3":   true,
+		"B3/S23/C2": false,
+		"B3/S23":    false,
+		"HighLife":  false,
+		"":          false,
+	} {
+		if got := IsMultiState(in); got != want {
+			t.Errorf("Expected IsMultiState(%q) to be %v, but got %v", in, want, got)
+		}
+	}
+}
+
+func TestGenerations_Next(t *testing.T) {
+	r, _ := ParseRule("B2/S3/C4")
+	cases := []struct {
+		state  uint8
+		firing int
+		want   uint8
+	}{
+		{0, 2, 1},
+		{0, 3, 0},
+		{1, 3, 1},
+		{1, 2, 2},
+		{2, 2, 3},
+		{3, 2, 0},
+	}
+	for _, c := range cases {
+		if got := r.Next(c.state, c.firing); got != c.want {
+			t.Errorf("Expected state %d with %d firing neighbours to become %d, but got %d", c.state, c.firing, c.want, got)
+		}
+	}
+	if name := r.StateName(3); name != "Dying 2" {
+		t.Errorf("Expected state 3 to be called Dying 2, but got %q", name)
+	}
+}
+
+func TestWireworld_Next(t *testing.T) {
+	var r Wireworld
+	cases := []struct {
+		state  uint8
+		firing int
+		want   uint8
+	}{
+		{Empty, 2, Empty},
+		{Head, 0, Tail},
+		{Tail, 1, Conductor},
+		{Conductor, 0, Conductor},
+		{Conductor, 1, Head},
+		{Conductor, 2, Head},
+		{Conductor, 3, Conductor},
+	}
+	for _, c := range cases {
+		if got := r.Next(c.state, c.firing); got != c.want {
+			t.Errorf("Expected %s with %d heads around to become %s, but got %s", r.StateName(c.state), c.firing, r.StateName(c.want), r.StateName(got))
+		}
+	}
+}
+
+func TestParseRule_LargerThanLife(t *testing.T) {
+	r, err := ParseRule("R2,C4,M0,S3..6,B4..5,NN")
+	if err != nil {
+		t.Fatalf("Expected a Larger-than-Life rule, but got %v", err)
+	}
+	if _, ok := r.(LargerThanLife); !ok || r.States() != 4 || r.String() != "R2,C4,M0,S3..6,B4..5,NN" {
+		t.Errorf("Expected a 4-state Larger-than-Life rule, but got %T %s", r, r)
+	}
+	if IsMultiState("R5,C0,M1,S34..58,B34..45,NM") {
+		t.Error("Expected a two-state Larger-than-Life rule not to need the multi-state board")
+	}
+	if _, err := ParseRule("R2,C4,S3..x,B4"); err == nil {
+		t.Error("Expected a bad Larger-than-Life rule to fail")
+	}
+}
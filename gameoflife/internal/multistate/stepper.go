@@ -0,0 +1,113 @@
+package multistate
+
+import (
+	gol "SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/internal/ddd"
+)
+
+// Stepper computes one generation of a board, reading current and writing
+// every cell of next. current and next must have the same size.
+type Stepper interface {
+	Step(current, next Board)
+}
+
+type stepper struct {
+	rule Rule
+	// next[state*9+firing] is the rule as a lookup table.
+	next []uint8
+}
+
+var _ Stepper = (*stepper)(nil)
+
+// ltlStepper counts a Larger-than-Life neighbourhood for the whole board at
+// once with gol.CountAll.
+type ltlStepper struct {
+	rule   LargerThanLife
+	counts []int
+	sat    []int32
+}
+
+var _ Stepper = (*ltlStepper)(nil)
+
+// NewStepper returns a single-threaded stepper for rule. Toroidal and bounded
+// boards are read straight from FlatSlice; the twisted topologies go through
+// Coordinate. Larger-than-Life rules count their neighbourhoods with a
+// summed-area table, so large radii stay fast.
+func NewStepper(rule Rule) Stepper {
+	if ltl, ok := rule.(LargerThanLife); ok {
+		return &ltlStepper{rule: ltl}
+	}
+	s := &stepper{rule: rule, next: make([]uint8, rule.States()*9)}
+	for state := 0; state < rule.States(); state++ {
+		for n := 0; n <= 8; n++ {
+			s.next[state*9+n] = rule.Next(uint8(state), n)
+		}
+	}
+	return s
+}
+
+func (s *stepper) Step(current, next Board) {
+	cols, rows := current.Cols(), current.Rows()
+	topology := current.Topology()
+	if topology != ddd.Toroidal && topology != ddd.Bounded {
+		for y := 0; y < rows; y++ {
+			for x := 0; x < cols; x++ {
+				next.SetCoordinate(x, y, s.nextState(current.Coordinate(x, y), current.CountFiring(x, y)))
+			}
+		}
+		return
+	}
+
+	in, out := current.FlatSlice(), next.FlatSlice()
+	wrap := topology == ddd.Toroidal
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			n := 0
+			for dy := -1; dy <= 1; dy++ {
+				ny := y + dy
+				if ny < 0 || ny >= rows {
+					if !wrap {
+						continue
+					}
+					ny = (ny + rows) % rows
+				}
+				for dx := -1; dx <= 1; dx++ {
+					nx := x + dx
+					if nx < 0 || nx >= cols {
+						if !wrap {
+							continue
+						}
+						nx = (nx + cols) % cols
+					}
+					if (dx != 0 || dy != 0) && in[ny*cols+nx] == 1 {
+						n++
+					}
+				}
+			}
+			out[y*cols+x] = s.nextState(in[y*cols+x], n)
+		}
+	}
+}
+
+// nextState looks up the rule; states the rule does not have become empty.
+func (s *stepper) nextState(state uint8, firing int) uint8 {
+	i := int(state)*9 + firing
+	if i >= len(s.next) {
+		return 0
+	}
+	return s.next[i]
+}
+
+func (s *ltlStepper) Step(current, next Board) {
+	cols, rows := current.Cols(), current.Rows()
+	if len(s.counts) != cols*rows {
+		s.counts = make([]int, cols*rows)
+	}
+	s.sat = gol.CountAll(s.rule.LtL().Neighborhood(), cols, rows, func(x, y int) bool {
+		return current.Coordinate(x, y) == 1
+	}, s.counts, s.sat)
+	in, out := current.FlatSlice(), next.FlatSlice()
+	for i, state := range in {
+		out[i] = s.rule.Next(state, s.counts[i])
+	}
+}
@@ -0,0 +1,129 @@
+package multistate
+
+import (
+	gol "SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/rng"
+	"slices"
+	"testing"
+)
+
+// load fills a board from rows of digits, one digit per state.
+func load(b Board, rows ...string) {
+	for y, row := range rows {
+		for x, ch := range row {
+			b.SetCoordinate(x, y, uint8(ch-'0'))
+		}
+	}
+}
+
+// dump writes a board back out as rows of digits.
+func dump(b Board) []string {
+	rows := make([]string, b.Rows())
+	for y := range rows {
+		row := make([]byte, b.Cols())
+		for x := range row {
+			row[x] = '0' + b.Coordinate(x, y)
+		}
+		rows[y] = string(row)
+	}
+	return rows
+}
+
+func TestStepper_WireworldElectronRunsAlongAWire(t *testing.T) {
+	for _, topology := range []ddd.Topology{ddd.Bounded, ddd.Toroidal, ddd.KleinBottle} {
+		current, next := NewBoard(6, 3, topology), NewBoard(6, 3, topology)
+		load(current, "000000", "213330", "000000")
+		s := NewStepper(Wireworld{})
+
+		s.Step(current, next)
+		want := []string{"000000", "321330", "000000"}
+		if got := dump(next); !slices.Equal(got, want) {
+			t.Errorf("%s: expected the electron to move right to %v, but got %v", topology, want, got)
+		}
+	}
+}
+
+func TestStepper_BriansBrainGlider(t *testing.T) {
+	rule, _ := ParseRule("Brian's Brain")
+	current, next := NewBoard(8, 8, ddd.Toroidal), NewBoard(8, 8, ddd.Toroidal)
+	// The smallest Brian's Brain spaceship moves one cell every generation.
+	load(current,
+		"00000000",
+		"00000000",
+		"00220000",
+		"00110000",
+		"00000000",
+	)
+	s := NewStepper(rule)
+	s.Step(current, next)
+	want := []string{
+		"00000000",
+		"00000000",
+		"00000000",
+		"00220000",
+		"00110000",
+		"00000000",
+		"00000000",
+		"00000000",
+	}
+	if got := dump(next); !slices.Equal(got, want) {
+		t.Errorf("Expected the spaceship to move down a row, but got %v", got)
+	}
+}
+
+func TestBoard_SeedBoard(t *testing.T) {
+	b := NewBoard(10, 10, ddd.Toroidal)
+	b.SetCoordinate(9, 9, 3)
+	b.SeedBoard(rng.New(1), gol.SeedOptions{Density: 1, X: 2, Y: 2, Width: 3, Height: 3})
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			want := uint8(0)
+			if x >= 2 && x < 5 && y >= 2 && y < 5 {
+				want = 1
+			}
+			if got := b.Coordinate(x, y); got != want {
+				t.Errorf("Expected (%d, %d) to be %d after seeding, but got %d", x, y, want, got)
+			}
+		}
+	}
+}
+
+func TestDefaultPalette(t *testing.T) {
+	rule, _ := ParseRule("B13/S2/C21")
+	p := DefaultPalette(rule)
+	if len(p) != 21 {
+		t.Fatalf("Expected a colour per state, but got %d", len(p))
+	}
+	for i := 1; i < len(p); i++ {
+		if p[i] == p[0] {
+			t.Errorf("Expected state %d to stand out from the empty state", i)
+		}
+	}
+	if p.Color(200) != p[0] {
+		t.Error("Expected unknown states to draw as empty")
+	}
+	if w := DefaultPalette(Wireworld{}); len(w) != 4 || w.Color(Head) == w.Color(Tail) {
+		t.Errorf("Expected four distinct Wireworld colours, but got %v", w)
+	}
+}
+
+func TestStepper_LargerThanLifeMatchesGenerations(t *testing.T) {
+	ltl, _ := ParseRule("R1,C3,M0,S2..3,B3,NM")
+	generations, _ := ParseRule("B3/S23/C3")
+	a, b := NewBoard(30, 20, ddd.Toroidal), NewBoard(30, 20, ddd.Toroidal)
+	a.SeedBoard(rng.New(4), gol.SeedOptions{Density: 0.4})
+	b.CopyBoard(a.FlatSlice())
+	scratchA, scratchB := NewBoard(30, 20, ddd.Toroidal), NewBoard(30, 20, ddd.Toroidal)
+	sa, sb := NewStepper(ltl), NewStepper(generations)
+
+	for gen := 1; gen <= 20; gen++ {
+		sa.Step(a, scratchA)
+		sb.Step(b, scratchB)
+		a, scratchA = scratchA, a
+		b, scratchB = scratchB, b
+		if !slices.Equal(a.FlatSlice(), b.FlatSlice()) {
+			t.Fatalf("Expected R1 Moore C3 to match B3/S23/C3, but generation %d differs", gen)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package runstate decides when the Game of Life advances: it tracks whether
+// the simulation is running or paused, the step interval and the generation
+// count, and turns player actions into board commands. It has no Ebiten
+// dependency so it can be tested on its own.
+package runstate
+
+import (
+	"fmt"
+	"time"
+)
+
+// State is whether the simulation advances on its own.
+type State uint8
+
+const (
+	Running State = iota
+	Paused
+)
+
+func (s State) String() string {
+	switch s {
+	case Running:
+		return "Running"
+	case Paused:
+		return "Paused"
+	}
+	return fmt.Sprintf("State(%d)", uint8(s))
+}
+
+// Action is a player command, usually bound to a key.
+type Action uint8
+
+const (
+	// TogglePause pauses a running simulation and resumes a paused one.
+	TogglePause Action = iota
+	// StepOnce advances a single generation; it only works while paused.
+	StepOnce
+	// Reset restores the seed the current run started from.
+	Reset
+	// Clear kills every cell.
+	Clear
+	// Reseed replaces the board with a fresh random soup.
+	Reseed
+	// Slower and Faster lengthen and shorten the step interval.
+	Slower
+	Faster
+)
+
+// Effect is what the caller has to do to the board after an Action.
+type Effect uint8
+
+const (
+	None Effect = iota
+	RestoreSeed
+	ClearBoard
+	NewSeed
+)
+
+const (
+	intervalStep = 10 * time.Millisecond
+	maxInterval  = 1000 * time.Millisecond
+)
+
+// Machine is the run-state machine for one simulation.
+type Machine interface {
+	State() State
+	Generation() int64
+	Interval() time.Duration
+	// Handle applies a player action and returns the board change it calls for.
+	Handle(action Action) Effect
+	// Due reports whether the simulation should advance a generation at now,
+	// and counts the generation when it should.
+	Due(now time.Time) bool
+	// JumpTo pauses at generation after the board was rewound or scrubbed to
+	// it, dropping any queued step.
+	JumpTo(generation int64)
+}
+
+type machine struct {
+	state      State
+	interval   time.Duration
+	lastStep   time.Time
+	generation int64
+	// pending is a single step requested while paused.
+	pending bool
+}
+
+var _ Machine = (*machine)(nil)
+
+// NewMachine returns a running machine that steps every interval.
+func NewMachine(interval time.Duration) Machine {
+	return newMachine(interval)
+}
+
+func newMachine(interval time.Duration) *machine {
+	return &machine{state: Running, interval: interval}
+}
+
+func (m *machine) State() State {
+	return m.state
+}
+
+func (m *machine) Generation() int64 {
+	return m.generation
+}
+
+func (m *machine) Interval() time.Duration {
+	return m.interval
+}
+
+func (m *machine) Handle(action Action) Effect {
+	switch action {
+	case TogglePause:
+		if m.state == Running {
+			m.state = Paused
+		} else {
+			m.state = Running
+		}
+		m.pending = false
+	case StepOnce:
+		if m.state == Paused {
+			m.pending = true
+		}
+	case Reset:
+		m.generation, m.pending = 0, false
+		return RestoreSeed
+	case Clear:
+		m.generation, m.pending = 0, false
+		return ClearBoard
+	case Reseed:
+		m.generation, m.pending = 0, false
+		return NewSeed
+	case Slower:
+		if m.interval < maxInterval {
+			m.interval = min(m.interval+intervalStep, maxInterval)
+		}
+	case Faster:
+		if m.interval >= intervalStep {
+			m.interval -= intervalStep
+		}
+	}
+	return None
+}
+
+func (m *machine) Due(now time.Time) bool {
+	switch {
+	case m.state == Paused && m.pending:
+		m.pending = false
+	case m.state == Running && now.Sub(m.lastStep) >= m.interval:
+	default:
+		return false
+	}
+	m.lastStep = now
+	m.generation++
+	return true
+}
+
+func (m *machine) JumpTo(generation int64) {
+	m.state, m.generation, m.pending = Paused, generation, false
+}
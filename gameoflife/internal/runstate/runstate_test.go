@@ -0,0 +1,113 @@
+package runstate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMachine_RunsOnInterval(t *testing.T) {
+	m := newMachine(100 * time.Millisecond)
+	start := time.Now()
+
+	if !m.Due(start) {
+		t.Error("Expected the first update to step")
+	}
+	if m.Due(start.Add(50 * time.Millisecond)) {
+		t.Error("Expected no step before the interval passed")
+	}
+	if !m.Due(start.Add(100 * time.Millisecond)) {
+		t.Error("Expected a step once the interval passed")
+	}
+	if m.Generation() != 2 {
+		t.Errorf("Expected generation 2, but got %d", m.Generation())
+	}
+}
+
+func TestMachine_PauseAndSingleStep(t *testing.T) {
+	m := newMachine(0)
+	now := time.Now()
+
+	m.Handle(TogglePause)
+	if m.State() != Paused {
+		t.Fatalf("Expected Paused, but got %v", m.State())
+	}
+	if m.Due(now) {
+		t.Error("Expected a paused machine not to step")
+	}
+
+	m.Handle(StepOnce)
+	if !m.Due(now) {
+		t.Error("Expected StepOnce to step while paused")
+	}
+	if m.Due(now) {
+		t.Error("Expected StepOnce to step only once")
+	}
+
+	m.Handle(TogglePause)
+	m.Handle(StepOnce)
+	if m.pending {
+		t.Error("Expected StepOnce to be ignored while running")
+	}
+	if m.State() != Running || !m.Due(now) {
+		t.Error("Expected the machine to run again after resuming")
+	}
+}
+
+func TestMachine_BoardActionsResetTheGeneration(t *testing.T) {
+	cases := map[Action]Effect{Reset: RestoreSeed, Clear: ClearBoard, Reseed: NewSeed}
+	for action, want := range cases {
+		m := newMachine(0)
+		m.Due(time.Now())
+		m.Handle(TogglePause)
+		m.Handle(StepOnce)
+
+		if got := m.Handle(action); got != want {
+			t.Errorf("Expected action %d to return effect %d, but got %d", action, want, got)
+		}
+		if m.Generation() != 0 || m.pending {
+			t.Errorf("Expected action %d to reset the generation and drop the queued step", action)
+		}
+		if m.State() != Paused {
+			t.Errorf("Expected action %d to keep the machine paused", action)
+		}
+	}
+}
+
+func TestMachine_JumpTo(t *testing.T) {
+	m := newMachine(0)
+	now := time.Now()
+	m.Due(now)
+	m.Due(now)
+	m.Handle(TogglePause)
+	m.Handle(StepOnce)
+
+	m.JumpTo(1)
+	if m.Generation() != 1 || m.State() != Paused || m.pending {
+		t.Fatalf("Expected JumpTo to pause at generation 1 with no queued step, but got %d, %v", m.Generation(), m.State())
+	}
+	m.Handle(TogglePause)
+	if !m.Due(now.Add(time.Second)) || m.Generation() != 2 {
+		t.Errorf("Expected the run to carry on from generation 1, but got %d", m.Generation())
+	}
+}
+
+func TestMachine_IntervalLimits(t *testing.T) {
+	m := newMachine(0)
+	m.Handle(Faster)
+	if m.Interval() != 0 {
+		t.Errorf("Expected the interval to stay at 0, but got %v", m.Interval())
+	}
+	for i := 0; i < 200; i++ {
+		m.Handle(Slower)
+	}
+	if m.Interval() != maxInterval {
+		t.Errorf("Expected the interval to stop at %v, but got %v", maxInterval, m.Interval())
+	}
+
+	// An interval off the step grid stops at the cap too, not past it.
+	m = newMachine(maxInterval - intervalStep/2)
+	m.Handle(Slower)
+	if m.Interval() != maxInterval {
+		t.Errorf("Expected the interval to be clamped to %v, but got %v", maxInterval, m.Interval())
+	}
+}
@@ -0,0 +1,145 @@
+package tools
+
+import "math"
+
+// Stamp calls fn for each cell of a size x size square brush centred on
+// (x, y). Even sizes extend one cell further right and down.
+func Stamp(x, y, size int, fn func(x, y int)) {
+	lo := -(size - 1) / 2
+	for dy := lo; dy < lo+size; dy++ {
+		for dx := lo; dx < lo+size; dx++ {
+			fn(x+dx, y+dy)
+		}
+	}
+}
+
+// Bresenham calls fn for each cell on the straight line from (x0, y0) to
+// (x1, y1), both ends included.
+func Bresenham(x0, y0, x1, y1 int, fn func(x, y int)) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+	for {
+		fn(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// Stroke stamps a size brush along the line from (x0, y0) to (x1, y1). Cells
+// under overlapping stamps are reported once.
+func Stroke(x0, y0, x1, y1, size int, fn func(x, y int)) {
+	if size == 1 {
+		Bresenham(x0, y0, x1, y1, fn)
+		return
+	}
+	seen := make(map[[2]int]bool)
+	Bresenham(x0, y0, x1, y1, func(x, y int) {
+		Stamp(x, y, size, func(x, y int) {
+			if !seen[[2]int{x, y}] {
+				seen[[2]int{x, y}] = true
+				fn(x, y)
+			}
+		})
+	})
+}
+
+// Outline calls fn for each cell on the border of the rectangle with corners
+// (x0, y0) and (x1, y1), drawn size cells thick towards the inside. Only the
+// border is visited, however large the rectangle.
+func Outline(x0, y0, x1, y1, size int, fn func(x, y int)) {
+	for _, strip := range span(x0, y0, x1, y1).border(size) {
+		strip.each(fn)
+	}
+}
+
+// Fill calls fn for each cell of the rectangle with corners (x0, y0) and
+// (x1, y1).
+func Fill(x0, y0, x1, y1 int, fn func(x, y int)) {
+	span(x0, y0, x1, y1).each(fn)
+}
+
+// area is the block of cells [minX, maxX] x [minY, maxY]. It is empty when
+// either maximum is below its minimum.
+type area struct {
+	minX, minY, maxX, maxY int
+}
+
+// everywhere is the area with no limits.
+var everywhere = area{math.MinInt, math.MinInt, math.MaxInt, math.MaxInt}
+
+// span is the area with corners (x0, y0) and (x1, y1).
+func span(x0, y0, x1, y1 int) area {
+	return area{min(x0, x1), min(y0, y1), max(x0, x1), max(y0, y1)}
+}
+
+func (a area) empty() bool {
+	return a.maxX < a.minX || a.maxY < a.minY
+}
+
+func (a area) contains(x, y int) bool {
+	return x >= a.minX && x <= a.maxX && y >= a.minY && y <= a.maxY
+}
+
+func (a area) intersect(b area) area {
+	return area{max(a.minX, b.minX), max(a.minY, b.minY), min(a.maxX, b.maxX), min(a.maxY, b.maxY)}
+}
+
+func (a area) each(fn func(x, y int)) {
+	for y := a.minY; y <= a.maxY; y++ {
+		for x := a.minX; x <= a.maxX; x++ {
+			fn(x, y)
+		}
+	}
+}
+
+// border splits the size cells thick border of a into at most four strips
+// that do not overlap: the top and bottom rows across the whole width, then
+// the left and right columns between them.
+func (a area) border(size int) []area {
+	if a.empty() {
+		return nil
+	}
+	size = max(size, 1)
+	top := area{a.minX, a.minY, a.maxX, min(a.minY+size-1, a.maxY)}
+	if top.maxY == a.maxY {
+		return []area{top}
+	}
+	bottom := area{a.minX, max(a.maxY-size+1, top.maxY+1), a.maxX, a.maxY}
+	if bottom.minY == top.maxY+1 {
+		return []area{top, bottom}
+	}
+	left := area{a.minX, top.maxY + 1, min(a.minX+size-1, a.maxX), bottom.minY - 1}
+	if left.maxX == a.maxX {
+		return []area{top, bottom, left}
+	}
+	right := area{max(a.maxX-size+1, left.maxX+1), left.minY, a.maxX, left.maxY}
+	return []area{top, bottom, left, right}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
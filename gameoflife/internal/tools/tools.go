@@ -0,0 +1,306 @@
+// Package tools implements the Game of Life editing tools: freehand brush,
+// straight lines and rectangles, each painting live cells or erasing them,
+// plus selection and stamping a pattern. It works in board cells and has no
+// Ebiten dependency, so the game only has to feed it mouse events.
+package tools
+
+import (
+	"SideProjectGames/internal/ddd"
+	"fmt"
+)
+
+// Kind is an editing tool.
+type Kind uint8
+
+const (
+	// Brush paints under the cursor while the button is held.
+	Brush Kind = iota
+	// Line draws a straight line from where the button went down to where
+	// it comes up.
+	Line
+	// Rectangle draws the outline of the rectangle spanned by the drag.
+	Rectangle
+	// FilledRectangle fills the rectangle spanned by the drag.
+	FilledRectangle
+	// Selector marks the rectangle spanned by the drag as the selection
+	// without changing any cells.
+	Selector
+	// Placer stamps the live cells of the pattern set with SetPattern,
+	// centred on the cursor; the eraser clears them instead.
+	Placer
+)
+
+var kindNames = map[Kind]string{
+	Brush:           "Brush",
+	Line:            "Line",
+	Rectangle:       "Rectangle",
+	FilledRectangle: "Filled Rectangle",
+	Selector:        "Select",
+	Placer:          "Pattern",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("Kind(%d)", uint8(k))
+}
+
+// MaxBrushSize is the widest brush, in cells.
+const MaxBrushSize = 32
+
+// PaintFunc sets the cell at (x, y) alive or dead.
+type PaintFunc func(x, y int, alive bool)
+
+// Toolbox holds the selected tool and brush size and turns a press, drag and
+// release of a mouse button into cell edits.
+type Toolbox interface {
+	Tool() Kind
+	Select(tool Kind)
+	BrushSize() int
+	SetBrushSize(size int)
+	// Press starts a stroke at cell (x, y); alive is false for the eraser.
+	Press(x, y int, alive bool)
+	// Drag moves an active stroke to (x, y).
+	Drag(x, y int)
+	// Release ends the active stroke at (x, y).
+	Release(x, y int)
+	// Active reports whether a stroke is in progress.
+	Active() bool
+	// Preview calls fn for each block of cells a pending line or rectangle
+	// would change if it were released now, so a large rectangle can be
+	// drawn as a few blocks rather than cell by cell.
+	Preview(fn func(x, y, width, height int))
+	// Selection returns the selected rectangle, including one still being
+	// dragged out with the Selector.
+	Selection() (x, y, width, height int, ok bool)
+	SetSelection(x, y, width, height int)
+	ClearSelection()
+	// SetBounds keeps selections, lines and rectangles on a cols x rows
+	// board, clipping whatever reaches past its edges, so they never wrap.
+	// Zero, the default, leaves them unbounded.
+	SetBounds(cols, rows int)
+	// SetView clips lines and rectangles to the width x height block of
+	// cells at (x, y), the part of an unbounded board on screen, so a drag
+	// across a zoomed out view does not visit cells nobody can see. A zero
+	// width or height, the default, removes the limit.
+	SetView(x, y, width, height int)
+	// SetPattern sets the pattern the Placer stamps; nil clears it.
+	SetPattern(pattern *ddd.Region[bool])
+	// RotatePattern turns the pattern 90 degrees clockwise.
+	RotatePattern()
+	// Ghost calls fn for each live cell of the pattern as the Placer would
+	// stamp it with the cursor on (x, y).
+	Ghost(x, y int, fn func(x, y int))
+}
+
+type toolbox struct {
+	paint PaintFunc
+	tool  Kind
+	size  int
+
+	active bool
+	alive  bool
+	// The stroke started at (startX, startY); (lastX, lastY) is the most
+	// recent cell it reached.
+	startX, startY int
+	lastX, lastY   int
+
+	selected  bool
+	selection [4]int
+	bounds    [2]int
+	view      area
+
+	pattern *ddd.Region[bool]
+}
+
+var _ Toolbox = (*toolbox)(nil)
+
+// NewToolbox returns a toolbox with a one cell Brush that applies its edits
+// through paint.
+func NewToolbox(paint PaintFunc) Toolbox {
+	return newToolbox(paint)
+}
+
+func newToolbox(paint PaintFunc) *toolbox {
+	return &toolbox{paint: paint, tool: Brush, size: 1, view: everywhere}
+}
+
+func (t *toolbox) Tool() Kind {
+	return t.tool
+}
+
+// Select changes tool. A stroke in progress is dropped.
+func (t *toolbox) Select(tool Kind) {
+	t.tool = tool
+	t.active = false
+}
+
+func (t *toolbox) BrushSize() int {
+	return t.size
+}
+
+// SetBrushSize clamps size to [1, MaxBrushSize].
+func (t *toolbox) SetBrushSize(size int) {
+	t.size = min(MaxBrushSize, max(1, size))
+}
+
+func (t *toolbox) Press(x, y int, alive bool) {
+	t.active, t.alive = true, alive
+	t.startX, t.startY, t.lastX, t.lastY = x, y, x, y
+	switch t.tool {
+	case Brush:
+		Stamp(x, y, t.size, t.emit)
+	case Selector:
+		t.selected = false
+	case Placer:
+		// A pattern lands once per click; dragging does not smear it.
+		t.Ghost(x, y, t.emit)
+	}
+}
+
+func (t *toolbox) Drag(x, y int) {
+	if !t.active {
+		return
+	}
+	if t.tool == Brush && (x != t.lastX || y != t.lastY) {
+		// Join the samples so a fast drag leaves no gaps.
+		Stroke(t.lastX, t.lastY, x, y, t.size, t.emit)
+	}
+	t.lastX, t.lastY = x, y
+}
+
+func (t *toolbox) Release(x, y int) {
+	if !t.active {
+		return
+	}
+	t.Drag(x, y)
+	switch t.tool {
+	case Brush, Placer:
+	case Selector:
+		x, y, width, height, _ := t.Selection()
+		t.SetSelection(x, y, width, height)
+	default:
+		t.shape(func(a area) { a.each(t.emit) })
+	}
+	t.active = false
+}
+
+func (t *toolbox) Active() bool {
+	return t.active
+}
+
+func (t *toolbox) Preview(fn func(x, y, width, height int)) {
+	if t.active && t.tool != Brush && t.tool != Selector {
+		t.shape(func(a area) { fn(a.minX, a.minY, a.maxX-a.minX+1, a.maxY-a.minY+1) })
+	}
+}
+
+func (t *toolbox) Selection() (x, y, width, height int, ok bool) {
+	if t.active && t.tool == Selector {
+		x, y = min(t.startX, t.lastX), min(t.startY, t.lastY)
+		return t.clip(x, y, max(t.startX, t.lastX)-x+1, max(t.startY, t.lastY)-y+1)
+	}
+	s := t.selection
+	return s[0], s[1], s[2], s[3], t.selected
+}
+
+func (t *toolbox) SetSelection(x, y, width, height int) {
+	x, y, width, height, t.selected = t.clip(x, y, width, height)
+	t.selection = [4]int{x, y, width, height}
+}
+
+func (t *toolbox) ClearSelection() {
+	t.selected = false
+}
+
+func (t *toolbox) SetBounds(cols, rows int) {
+	t.bounds = [2]int{cols, rows}
+	if t.selected {
+		s := t.selection
+		t.SetSelection(s[0], s[1], s[2], s[3])
+	}
+}
+
+func (t *toolbox) SetView(x, y, width, height int) {
+	t.view = everywhere
+	if width > 0 && height > 0 {
+		t.view = area{x, y, x + width - 1, y + height - 1}
+	}
+}
+
+// limits is the area lines and rectangles are clipped to.
+func (t *toolbox) limits() area {
+	a := t.view
+	if cols, rows := t.bounds[0], t.bounds[1]; cols > 0 && rows > 0 {
+		a = a.intersect(area{0, 0, cols - 1, rows - 1})
+	}
+	return a
+}
+
+// clip cuts a rectangle down to the bounds, if there are any, and reports
+// whether anything is left of it.
+func (t *toolbox) clip(x, y, width, height int) (int, int, int, int, bool) {
+	if cols, rows := t.bounds[0], t.bounds[1]; cols > 0 && rows > 0 {
+		x0, y0 := max(x, 0), max(y, 0)
+		x1, y1 := min(x+width, cols), min(y+height, rows)
+		x, y, width, height = x0, y0, x1-x0, y1-y0
+	}
+	return x, y, width, height, width > 0 && height > 0
+}
+
+func (t *toolbox) SetPattern(pattern *ddd.Region[bool]) {
+	t.pattern = pattern
+}
+
+func (t *toolbox) RotatePattern() {
+	if t.pattern != nil {
+		t.pattern = t.pattern.Rotate()
+	}
+}
+
+// Ghost centres the pattern on (x, y), rounding towards the top-left.
+func (t *toolbox) Ghost(x, y int, fn func(x, y int)) {
+	p := t.pattern
+	if p == nil {
+		return
+	}
+	x, y = x-(p.Width-1)/2, y-(p.Height-1)/2
+	for dy := 0; dy < p.Height; dy++ {
+		for dx := 0; dx < p.Width; dx++ {
+			if p.At(dx, dy) {
+				fn(x+dx, y+dy)
+			}
+		}
+	}
+}
+
+// shape calls fn for the blocks of the pending line or rectangle that lie
+// within the limits: single cells along a line, the strips of an outline or
+// the whole of a filled rectangle.
+func (t *toolbox) shape(fn func(a area)) {
+	limits := t.limits()
+	rect := span(t.startX, t.startY, t.lastX, t.lastY)
+	switch t.tool {
+	case Line:
+		Stroke(t.startX, t.startY, t.lastX, t.lastY, t.size, func(x, y int) {
+			if limits.contains(x, y) {
+				fn(area{x, y, x, y})
+			}
+		})
+	case Rectangle:
+		for _, strip := range rect.border(t.size) {
+			if strip = strip.intersect(limits); !strip.empty() {
+				fn(strip)
+			}
+		}
+	case FilledRectangle:
+		if rect = rect.intersect(limits); !rect.empty() {
+			fn(rect)
+		}
+	}
+}
+
+func (t *toolbox) emit(x, y int) {
+	t.paint(x, y, t.alive)
+}
@@ -0,0 +1,248 @@
+package tools
+
+import (
+	"SideProjectGames/internal/ddd"
+	"testing"
+)
+
+// recorder collects the edits a toolbox makes.
+type recorder map[[2]int]bool
+
+func (r recorder) paint(x, y int, alive bool) {
+	r[[2]int{x, y}] = alive
+}
+
+func collect(draw func(fn func(x, y int))) map[[2]int]int {
+	cells := make(map[[2]int]int)
+	draw(func(x, y int) { cells[[2]int{x, y}]++ })
+	return cells
+}
+
+// blocks expands the blocks a preview reports into cells.
+func blocks(preview func(fn func(x, y, width, height int))) func(fn func(x, y int)) {
+	return func(fn func(x, y int)) {
+		preview(func(x, y, width, height int) {
+			Fill(x, y, x+width-1, y+height-1, fn)
+		})
+	}
+}
+
+func TestBresenham_IsContinuous(t *testing.T) {
+	for _, end := range [][2]int{{7, 3}, {-4, 9}, {0, -5}, {-6, -6}, {0, 0}} {
+		var cells [][2]int
+		Bresenham(0, 0, end[0], end[1], func(x, y int) { cells = append(cells, [2]int{x, y}) })
+
+		if cells[0] != [2]int{0, 0} || cells[len(cells)-1] != end {
+			t.Errorf("Expected the line to run from (0, 0) to %v, but got %v", end, cells)
+		}
+		for i := 1; i < len(cells); i++ {
+			if abs(cells[i][0]-cells[i-1][0]) > 1 || abs(cells[i][1]-cells[i-1][1]) > 1 {
+				t.Errorf("Expected neighbouring cells along the line to %v, but got a gap at %v", end, cells[i])
+			}
+		}
+	}
+}
+
+func TestShapes(t *testing.T) {
+	if got := collect(func(fn func(x, y int)) { Stamp(5, 5, 3, fn) }); len(got) != 9 || got[[2]int{4, 4}] != 1 || got[[2]int{6, 6}] != 1 {
+		t.Errorf("Expected a 3x3 stamp around (5, 5), but got %v", got)
+	}
+	if got := collect(func(fn func(x, y int)) { Stamp(0, 0, 2, fn) }); got[[2]int{1, 1}] != 1 || got[[2]int{-1, -1}] != 0 {
+		t.Errorf("Expected an even stamp to extend right and down, but got %v", got)
+	}
+	if got := collect(func(fn func(x, y int)) { Outline(4, 4, 0, 0, 1, fn) }); len(got) != 16 || got[[2]int{2, 2}] != 0 {
+		t.Errorf("Expected a hollow 5x5 outline of 16 cells, but got %v", got)
+	}
+	if got := collect(func(fn func(x, y int)) { Outline(0, 0, 4, 4, 2, fn) }); len(got) != 24 {
+		t.Errorf("Expected a two cell thick outline of 24 cells, but got %d", len(got))
+	}
+	if got := collect(func(fn func(x, y int)) { Fill(0, 3, 2, 0, fn) }); len(got) != 12 {
+		t.Errorf("Expected a filled 3x4 rectangle, but got %d cells", len(got))
+	}
+	if got := collect(func(fn func(x, y int)) { Outline(0, 0, 5, 2, 2, fn) }); len(got) != 18 {
+		t.Errorf("Expected an outline thicker than the rectangle to fill it, but got %d cells", len(got))
+	}
+	n := 0
+	Outline(0, 0, 1<<20, 1<<20, 1, func(x, y int) { n++ })
+	if n != 4<<20 {
+		t.Errorf("Expected only the %d border cells of a huge outline, but got %d", 4<<20, n)
+	}
+	for cell, n := range collect(func(fn func(x, y int)) { Stroke(0, 0, 10, 4, 3, fn) }) {
+		if n != 1 {
+			t.Errorf("Expected each stroke cell once, but %v was reported %d times", cell, n)
+		}
+	}
+}
+
+func TestToolbox_BrushPaintsWhileDragging(t *testing.T) {
+	r := recorder{}
+	tb := newToolbox(r.paint)
+
+	tb.Press(0, 0, true)
+	tb.Drag(5, 0)
+	tb.Release(5, 0)
+
+	for x := 0; x <= 5; x++ {
+		if !r[[2]int{x, 0}] {
+			t.Errorf("Expected (%d, 0) to be painted", x)
+		}
+	}
+	if tb.Active() {
+		t.Error("Expected the stroke to end on release")
+	}
+}
+
+func TestToolbox_EraserAndShapes(t *testing.T) {
+	r := recorder{}
+	tb := newToolbox(r.paint)
+
+	tb.Select(Line)
+	tb.Press(0, 0, false)
+	tb.Drag(3, 3)
+	if len(r) != 0 {
+		t.Error("Expected a line to change nothing until it is released")
+	}
+	if preview := collect(blocks(tb.Preview)); len(preview) != 4 {
+		t.Errorf("Expected a 4 cell preview, but got %v", preview)
+	}
+	tb.Release(3, 3)
+	if alive, ok := r[[2]int{2, 2}]; !ok || alive {
+		t.Errorf("Expected the right button to erase along the line, but got %v", r)
+	}
+
+	r = recorder{}
+	tb = newToolbox(r.paint)
+	tb.Select(FilledRectangle)
+	tb.SetBrushSize(5)
+	tb.Press(1, 1, true)
+	tb.Release(2, 2)
+	if len(r) != 4 {
+		t.Errorf("Expected a filled 2x2 rectangle, but got %v", r)
+	}
+}
+
+func TestToolbox_BrushSizeIsClamped(t *testing.T) {
+	tb := newToolbox(func(int, int, bool) {})
+	tb.SetBrushSize(0)
+	if tb.BrushSize() != 1 {
+		t.Errorf("Expected brush size 1, but got %d", tb.BrushSize())
+	}
+	tb.SetBrushSize(1000)
+	if tb.BrushSize() != MaxBrushSize {
+		t.Errorf("Expected brush size %d, but got %d", MaxBrushSize, tb.BrushSize())
+	}
+}
+
+func TestToolbox_Select(t *testing.T) {
+	r := recorder{}
+	tb := newToolbox(r.paint)
+	tb.Select(Selector)
+
+	tb.Press(5, 4, true)
+	tb.Drag(2, 6)
+	if x, y, w, h, ok := tb.Selection(); !ok || x != 2 || y != 4 || w != 4 || h != 3 {
+		t.Errorf("Expected the selection being dragged to be 4x3 at (2, 4), but got %dx%d at (%d, %d)", w, h, x, y)
+	}
+	tb.Release(2, 6)
+	if len(r) != 0 {
+		t.Errorf("Expected selecting to change no cells, but got %v", r)
+	}
+	if _, _, w, h, ok := tb.Selection(); !ok || w != 4 || h != 3 {
+		t.Error("Expected the selection to stay after release")
+	}
+
+	tb.ClearSelection()
+	if _, _, _, _, ok := tb.Selection(); ok {
+		t.Error("Expected no selection after clearing it")
+	}
+}
+
+func TestToolbox_SelectionStaysInBounds(t *testing.T) {
+	tb := newToolbox(recorder{}.paint)
+	tb.SetBounds(10, 8)
+	tb.Select(Selector)
+
+	tb.Press(7, 6, true)
+	tb.Drag(12, 9)
+	if x, y, w, h, ok := tb.Selection(); !ok || x != 7 || y != 6 || w != 3 || h != 2 {
+		t.Errorf("Expected the selection being dragged clipped to 3x2 at (7, 6), but got %dx%d at (%d, %d)", w, h, x, y)
+	}
+	tb.Release(12, 9)
+
+	tb.SetSelection(-2, 5, 4, 6)
+	if x, y, w, h, ok := tb.Selection(); !ok || x != 0 || y != 5 || w != 2 || h != 3 {
+		t.Errorf("Expected the selection clipped to 2x3 at (0, 5), but got %dx%d at (%d, %d)", w, h, x, y)
+	}
+	tb.SetSelection(10, 0, 3, 3)
+	if _, _, _, _, ok := tb.Selection(); ok {
+		t.Error("Expected a selection entirely off the board to be dropped")
+	}
+}
+
+func TestToolbox_ShapesStayInTheLimits(t *testing.T) {
+	r := recorder{}
+	tb := newToolbox(r.paint)
+	tb.SetBounds(10, 8)
+	tb.Select(FilledRectangle)
+	tb.Press(-1000, -1000, true)
+	tb.Drag(4, 3)
+	var previews int
+	tb.Preview(func(x, y, w, h int) {
+		previews++
+		if x != 0 || y != 0 || w != 5 || h != 4 {
+			t.Errorf("Expected the preview clipped to 5x4 at (0, 0), but got %dx%d at (%d, %d)", w, h, x, y)
+		}
+	})
+	if previews != 1 {
+		t.Errorf("Expected a filled rectangle to preview as one block, but got %d", previews)
+	}
+	tb.Release(4, 3)
+	if len(r) != 20 {
+		t.Errorf("Expected the 20 cells on the board, but got %d", len(r))
+	}
+
+	r = recorder{}
+	tb = newToolbox(r.paint)
+	tb.SetView(-5, -5, 10, 10)
+	tb.Select(Rectangle)
+	tb.Press(-1<<40, 0, true)
+	tb.Release(1<<40, 2)
+	for cell := range r {
+		if cell[0] < -5 || cell[0] > 4 || cell[1] < 0 || cell[1] > 2 {
+			t.Errorf("Expected the rectangle clipped to the view, but got %v", cell)
+		}
+	}
+	if len(r) != 20 {
+		t.Errorf("Expected the top and bottom rows of the view, 20 cells, but got %d", len(r))
+	}
+}
+
+func TestToolbox_Placer(t *testing.T) {
+	r := recorder{}
+	tb := newToolbox(r.paint)
+	tb.Select(Placer)
+
+	// Without a pattern a click does nothing.
+	tb.Press(0, 0, true)
+	tb.Release(0, 0)
+	if len(r) != 0 {
+		t.Errorf("Expected no edits without a pattern, but got %v", r)
+	}
+
+	// A 3x1 blinker with a dead cell at its right end.
+	pattern := ddd.NewRegion[bool](3, 1)
+	pattern.Set(0, 0, true)
+	pattern.Set(1, 0, true)
+	tb.SetPattern(pattern)
+	if ghost := collect(func(fn func(x, y int)) { tb.Ghost(10, 10, fn) }); len(ghost) != 2 || ghost[[2]int{9, 10}] != 1 || ghost[[2]int{10, 10}] != 1 {
+		t.Errorf("Expected the ghost to centre the live cells on (10, 10), but got %v", ghost)
+	}
+
+	tb.RotatePattern()
+	tb.Press(10, 10, true)
+	tb.Drag(20, 20)
+	tb.Release(20, 20)
+	if len(r) != 2 || !r[[2]int{10, 9}] || !r[[2]int{10, 10}] {
+		t.Errorf("Expected one vertical stamp at the press, but got %v", r)
+	}
+}
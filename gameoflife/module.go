@@ -1,187 +1,585 @@
 package gameoflife
 
 import (
+	"SideProjectGames/gameoflife/internal/camera"
 	"SideProjectGames/gameoflife/internal/ddd"
+	"SideProjectGames/gameoflife/internal/engine"
+	"SideProjectGames/gameoflife/internal/export"
+	"SideProjectGames/gameoflife/internal/history"
+	"SideProjectGames/gameoflife/internal/library"
+	"SideProjectGames/gameoflife/internal/multistate"
+	"SideProjectGames/gameoflife/internal/runstate"
+	"SideProjectGames/gameoflife/internal/tools"
+	"SideProjectGames/internal/clipboard"
 	"SideProjectGames/internal/config"
+	core "SideProjectGames/internal/ddd"
+	"SideProjectGames/internal/module"
+	"SideProjectGames/internal/rng"
+	"SideProjectGames/internal/scene"
 	"bytes"
 	"fmt"
-	"image/color"
-	"log"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 var (
 	mplusFaceSource *text.GoTextFaceSource
 )
 
+func init() {
+	module.Register(golModule{})
+}
+
+// golModule exposes the Game of Life to the module registry.
+type golModule struct{}
+
+func (golModule) Name() string { return "GOL" }
+
+func (golModule) Description() string { return "Conway's Game of Life" }
+
+func (golModule) Run(cfg config.AppConfig) error { return Run(cfg) }
+
+func (golModule) NewScene(cfg config.AppConfig) (scene.Scene, error) { return NewScene(cfg) }
+
 // Run launches an Ebiten window to visualize Conway's Game of Life using the provided config.
 // It replaces the previous CLI printing loop with a graphical, interactive loop.
 func Run(cfg config.AppConfig) error {
+	s, err := NewScene(cfg)
+	if err != nil {
+		return err
+	}
+	return scene.Run(s)
+}
+
+// NewScene builds a Game of Life scene that can be hosted by a scene.Manager.
+func NewScene(cfg config.AppConfig) (scene.Scene, error) {
+	var err error
+
+	unbounded := ddd.IsUnbounded(cfg.EDGEMODE)
+	topology := core.Toroidal
+	if !unbounded {
+		if topology, err = core.ParseTopology(cfg.EDGEMODE); err != nil {
+			return nil, err
+		}
+	}
+
+	var pattern *ddd.Pattern
+	if cfg.GOLPATTERN != "" {
+		if pattern, err = loadPattern(cfg.GOLPATTERN); err != nil {
+			return nil, err
+		}
+	}
+
+	// An explicit GOLRULE wins; otherwise use the pattern's own rule, if it names one.
+	ruleString := cfg.GOLRULE
+	if ruleString == "" && pattern != nil {
+		ruleString = pattern.Rule
+	}
+	soup, err := seedOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if multistate.IsMultiState(ruleString) {
+		// Rules with more than two states get their own scene.
+		switch {
+		case unbounded:
+			return nil, fmt.Errorf("rule %s: multi-state rules need a fixed board, not EDGE_MODE=%s", ruleString, cfg.EDGEMODE)
+		case pattern != nil:
+			return nil, fmt.Errorf("rule %s: patterns can only be loaded for two-state rules", ruleString)
+		}
+		msRule, _ := multistate.ParseRule(ruleString)
+		a, err := newAutomaton(cfg, msRule, topology, soup)
+		if err != nil {
+			return nil, err
+		}
+		return a, nil
+	}
+	var rule ddd.Rule
+	var ltl *ddd.LtLRule
+	if ddd.IsLtL(ruleString) {
+		r, err := ddd.ParseLtL(ruleString)
+		if err != nil {
+			return nil, err
+		}
+		if unbounded {
+			return nil, fmt.Errorf("rule %s: Larger-than-Life rules need a fixed board, not EDGE_MODE=%s", ruleString, cfg.EDGEMODE)
+		}
+		ltl = &r
+	} else if rule, err = ddd.ParseRule(ruleString); err != nil {
+		return nil, err
+	}
+
 	g := &game{
-		cellSize:  10,
-		stepEvery: time.Millisecond * 100,
-		read:      ddd.NewGOLBoard(cfg.GOLWIDTH, cfg.GOLHEIGHT),
-		write:     ddd.NewGOLBoard(cfg.GOLWIDTH, cfg.GOLHEIGHT),
+		skipCord: make(map[skippableItems]struct{}),
+		cam:      camera.New(initialZoom),
+		run:      runstate.NewMachine(time.Millisecond * 100),
+		detector: engine.NewCycleDetector(engine.DefaultHistory),
+		rule:     rule,
+		viewCols: cfg.GOLWIDTH,
+		viewRows: cfg.GOLHEIGHT,
+		rngSeed:  cfg.SEED,
+		random:   rng.New(cfg.SEED).Derive("GOL"),
+		soup:     soup,
 	}
-	g.read.SeedBoard()
+	g.ltl = ltl
+	g.history = history.New(history.DefaultCapacity, history.DefaultKeyframeEvery, history.DefaultMaxCells)
+	g.undo = core.NewUndoStack[bool](core.DefaultUndoLimit)
+	g.exportDir = cfg.GOLEXPORTDIR
+	g.exportStyle = export.DefaultStyle
+	g.exportStyle.CellSize = max(cfg.GOLEXPORTCELL, 1)
+	g.exportStyle.Grid = cfg.GOLEXPORTGRID
 
+	if unbounded {
+		// GOLWIDTH x GOLHEIGHT is only the size of the view; the universe grows as needed.
+		if rule.Born(0) {
+			return nil, fmt.Errorf("EDGE_MODE=INFINITE: %s: %w", rule, engine.ErrBirthOnZero)
+		}
+		g.sparseRead = ddd.NewSparseBoard()
+		g.sparseWrite = ddd.NewSparseBoard()
+		g.sparseStepper = engine.NewSparseStepper(rule)
+		if pattern != nil {
+			pattern.Place(g.sparseRead, 0, 0)
+		} else {
+			g.sparseRead.SeedRegion(g.random, g.soupAt(0, 0))
+		}
+	} else {
+		if ltl != nil {
+			g.stepper = engine.NewLtLStepper(*ltl)
+		} else if g.stepper, err = engine.NewStepper(cfg.GOLSTEPPER, rule, cfg.GOLWORKERS); err != nil {
+			return nil, err
+		}
+		if g.read, err = ddd.NewBoardOfKind(cfg.GOLBOARD, cfg.GOLWIDTH, cfg.GOLHEIGHT, topology); err != nil {
+			return nil, err
+		}
+		g.write, _ = ddd.NewBoardOfKind(cfg.GOLBOARD, cfg.GOLWIDTH, cfg.GOLHEIGHT, topology)
+		if pattern != nil {
+			if err := pattern.PlaceCentered(g.read); err != nil {
+				return nil, fmt.Errorf("%s: %w", cfg.GOLPATTERN, err)
+			}
+		} else {
+			g.read.SeedBoard(g.random, g.soup)
+		}
+	}
+	g.saveSeed()
+	g.remember(g.run.Generation())
+	g.tools = tools.NewToolbox(g.paint)
+	if !unbounded {
+		// Selections, lines and rectangles are clipped to the board rather
+		// than wrapped.
+		g.tools.SetBounds(g.read.Cols(), g.read.Rows())
+	}
+	if g.library, err = library.Load(); err != nil {
+		return nil, err
+	}
+	g.pick(0)
+	g.clipboard = clipboard.New()
+	g.screenW, g.screenH = g.WindowSize()
+	if unbounded {
+		g.centerCamera()
+	} else {
+		g.fitView()
+	}
+
+	if err := loadFont(); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// loadFont loads the HUD font the first time a scene needs it.
+func loadFont() error {
+	if mplusFaceSource != nil {
+		return nil
+	}
 	s, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-
 	mplusFaceSource = s
+	return nil
+}
 
-	// Size in pixels
-	w := cfg.GOLWIDTH * g.cellSize
-	h := cfg.GOLHEIGHT * g.cellSize
-	ebiten.SetWindowSize(w, h)
-	ebiten.SetWindowTitle("Conway's Game of Life")
+// seedOptions reads GOLDENSITY, GOLSEEDREGION and GOLSYMMETRY.
+func seedOptions(cfg config.AppConfig) (ddd.SeedOptions, error) {
+	opts := ddd.SeedOptions{Density: cfg.GOLDENSITY}
+	if opts.Density < 0 || opts.Density > 1 {
+		return opts, fmt.Errorf("GOLDENSITY %v: expected a value between 0 and 1", cfg.GOLDENSITY)
+	}
+	var err error
+	if opts.X, opts.Y, opts.Width, opts.Height, err = ddd.ParseRegion(cfg.GOLSEEDREGION); err != nil {
+		return opts, fmt.Errorf("GOLSEEDREGION: %w", err)
+	}
+	if opts.Symmetry, err = ddd.ParseSymmetry(cfg.GOLSYMMETRY); err != nil {
+		return opts, fmt.Errorf("GOLSYMMETRY: %w", err)
+	}
+	return opts, nil
+}
 
-	return ebiten.RunGame(g)
+// soupAt is the unbounded soup: the configured region, or else a view-sized
+// patch with its top-left corner at (x, y).
+func (g *game) soupAt(x, y int) ddd.SeedOptions {
+	opts := g.soup
+	if opts.Width <= 0 || opts.Height <= 0 {
+		opts.X, opts.Y, opts.Width, opts.Height = x, y, g.viewCols, g.viewRows
+	}
+	return opts
+}
+
+// loadPattern reads the pattern file at path in any supported format.
+func loadPattern(path string) (*ddd.Pattern, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p, _, err := ddd.ReadPattern(path, f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
 }
 
 type skippableItems struct {
-	row int16
-	col int16
+	row int
+	col int
 }
 
 type game struct {
-	read      ddd.GolBoard
-	write     ddd.GolBoard
-	skipCord  []skippableItems
-	cellSize  int
-	stepEvery time.Duration
-	lastStep  time.Time
+	read     ddd.GolBoard
+	write    ddd.GolBoard
+	skipCord map[skippableItems]struct{}
+	rule     ddd.Rule
+	ltl      *ddd.LtLRule
+	stepper  engine.Stepper
+	run      runstate.Machine
+	// detector watches for the board settling; objects summarizes what it
+	// settled into.
+	detector engine.CycleDetector
+	objects  string
+	// history holds past generations for B and the timeline. historyDirty
+	// is set when the current generation was edited after it was recorded;
+	// scrubbing is set while the timeline is being dragged.
+	history      history.History
+	historyDirty bool
+	scrubbing    bool
+	// seed is the generation R restores; sparseSeed in unbounded mode.
+	seed       []bool
+	sparseSeed ddd.SparseBoard
+	// rngSeed is the SEED the session started from, shown in the HUD so a
+	// run can be repeated; random is this module's stream from it and soup
+	// describes the random fills S makes.
+	rngSeed int64
+	random  rng.Source
+	soup    ddd.SeedOptions
+
+	// In unbounded mode the sparse boards replace read and write.
+	sparseRead    ddd.SparseBoard
+	sparseWrite   ddd.SparseBoard
+	sparseStepper *engine.SparseStepper
+
+	// viewCols x viewRows is the GOLWIDTH x GOLHEIGHT the window opens on;
+	// the camera decides what is on screen after that.
+	viewCols, viewRows int
+	cam                camera.Camera
+	screenW, screenH   int
+	drag               mouseDrag
+
+	tools      tools.Toolbox
+	toolButton ebiten.MouseButton
+	clipboard  clipboard.Clipboard
+	// undo holds the edits for Ctrl+Z and Ctrl+Y, tagged with the generation
+	// they were made on; stroke is set while a tool gesture is being
+	// recorded as one edit.
+	undo   core.UndoStack[bool]
+	stroke bool
+	// library is the built-in pattern catalog; picked is the entry the
+	// Placer stamps.
+	library library.Catalog
+	picked  int
+	// message is a one-line status, such as the result of a paste.
+	message string
+	// exportDir and exportStyle are where and how P and G save images;
+	// recording is the GIF G is recording, if any.
+	exportDir   string
+	exportStyle export.Style
+	recording   export.Recorder
+
+	// canvas holds the board drawn one pixel per sample when zoomed out.
+	canvas *ebiten.Image
+	pixels []byte
+}
+
+var (
+	_ scene.Windowed  = (*game)(nil)
+	_ scene.Resizable = (*game)(nil)
+)
+
+func (g *game) Title() string {
+	return "Conway's Game of Life"
+}
+
+// WindowSize is the board (or, when unbounded, the view) size at the initial
+// zoom, capped so huge boards still open a window that fits on screen.
+func (g *game) WindowSize() (int, int) {
+	return min(g.viewCols*initialZoom, maxWindowWidth), min(g.viewRows*initialZoom, maxWindowHeight)
+}
+
+func (g *game) Resizable() bool {
+	return true
+}
+
+// ruleName is the rule in its canonical notation: ltl when GOLRULE is a
+// Larger-than-Life rule, which replaces rule, or else rule.
+func (g *game) ruleName() string {
+	if g.ltl != nil {
+		return g.ltl.String()
+	}
+	return g.rule.String()
+}
+
+func (g *game) unbounded() bool {
+	return g.sparseRead != nil
+}
+
+// setCell edits the current generation in both buffers.
+func (g *game) setCell(x, y int, alive bool) {
+	if g.unbounded() {
+		g.sparseRead.SetCoordinate(x, y, alive)
+		g.sparseWrite.SetCoordinate(x, y, alive)
+		return
+	}
+	g.write.SetCoordinate(x, y, alive)
+	g.read.SetCoordinate(x, y, alive)
+	g.touch(x, y)
+}
+
+// touch tells a change-tracking stepper that (x, y) was edited outside a step.
+func (g *game) touch(x, y int) {
+	if tracker, ok := g.stepper.(engine.ChangeTracker); ok {
+		tracker.Touch(x, y)
+	}
 }
 
 func (g *game) Update() error {
+	// Edits from here on belong to the current generation.
+	g.undo.SetTag(g.run.Generation())
 	// Step the simulation at fixed intervals
 	g.handleClick()
-	if time.Since(g.lastStep) >= g.stepEvery {
+	g.handleCamera()
+	g.handleSelection()
+	g.handlePicker()
+	g.handleKeys()
+	g.handleCapture()
+	g.handleTimeline()
+	if g.run.Due(time.Now()) {
+		if g.historyDirty {
+			// Keep the edited generation, branching the history there. Due
+			// has already counted the step about to be taken.
+			g.remember(g.run.Generation() - 1)
+		}
 		g.step()
-		g.lastStep = time.Now()
 		g.wipeSkippable()
+		g.observe()
+		g.record()
 	}
 	return nil
 }
 
-func (g *game) addSkippable(item skippableItems) {
-	g.skipCord = append(g.skipCord, item)
+// runKeys binds keys to run-state actions.
+var runKeys = map[ebiten.Key]runstate.Action{
+	ebiten.KeySpace: runstate.TogglePause,
+	ebiten.KeyN:     runstate.StepOnce,
+	ebiten.KeyR:     runstate.Reset,
+	ebiten.KeyC:     runstate.Clear,
+	ebiten.KeyS:     runstate.Reseed,
+	// The arrow keys pan the camera, so speed lives on the bracket keys.
+	ebiten.KeyBracketLeft:  runstate.Slower,
+	ebiten.KeyBracketRight: runstate.Faster,
 }
 
-func (g *game) wipeSkippable() {
-	g.skipCord = []skippableItems{}
+func (g *game) handleKeys() {
+	if ctrlHeld() {
+		// Leave Ctrl+C and friends to the clipboard.
+		return
+	}
+	for key, action := range runKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			g.apply(g.run.Handle(action))
+		}
+	}
 }
 
-func (g *game) Draw(screen *ebiten.Image) {
-	// Clear
-	screen.Fill(color.RGBA{A: 255})
+// apply carries out the board change a run-state action asked for.
+func (g *game) apply(effect runstate.Effect) {
+	switch effect {
+	case runstate.None:
+		return
+	case runstate.RestoreSeed:
+		if g.unbounded() {
+			copySparse(g.sparseRead, g.sparseSeed)
+		} else {
+			g.read.CopyBoard(g.seed)
+		}
+	case runstate.ClearBoard:
+		if g.unbounded() {
+			g.sparseRead.Clear()
+		} else {
+			g.read.CopyBoard(make([]bool, g.read.Cols()*g.read.Rows()))
+		}
+	case runstate.NewSeed:
+		if g.unbounded() {
+			// Seed a GOLWIDTH x GOLHEIGHT patch in the middle of the screen.
+			cx, cy := g.cam.ScreenToWorld(float64(g.screenW)/2, float64(g.screenH)/2)
+			g.sparseRead.Clear()
+			g.sparseRead.SeedRegion(g.random, g.soupAt(int(cx)-g.viewCols/2, int(cy)-g.viewRows/2))
+		} else {
+			g.read.SeedBoard(g.random, g.soup)
+		}
+		g.saveSeed()
+	}
 
-	// Draw alive cells as white rectangles
-	cs := g.cellSize
-	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
-	for y := 0; y < g.read.Rows(); y++ {
-		for x := 0; x < g.read.Cols(); x++ {
-			if g.read.Coordinate(x, y) { // Corrected: Coordinate(x, y)
-				xPix := x * cs
-				yPix := y * cs
+	// The board changed wholesale, so nothing carries over to the next step.
+	g.wipeSkippable()
+	g.forgetCycle()
+	g.undo.Clear()
+	if tracker, ok := g.stepper.(engine.ChangeTracker); ok {
+		tracker.Reset()
+	}
+}
 
-				vector.DrawFilledRect(screen, float32(xPix), float32(yPix), float32(cs-1), float32(cs-1), white, false)
-			}
-		}
+// saveSeed remembers the current generation as the one R restores.
+func (g *game) saveSeed() {
+	if g.unbounded() {
+		g.sparseSeed = ddd.NewSparseBoard()
+		copySparse(g.sparseSeed, g.sparseRead)
+		return
 	}
+	g.seed = slices.Clone(g.read.FlatSlice())
+}
 
-	//msg := strconv.FormatInt(int64(g.stepEvery), 10)
-	msg := fmt.Sprintf("Step Time: %v", g.stepEvery)
+// copySparse replaces the cells of dst with those of src.
+func copySparse(dst, src ddd.SparseBoard) {
+	dst.Clear()
+	for _, key := range src.Tiles() {
+		tile, _ := src.Tile(key)
+		dst.SetTile(key, tile)
+	}
+}
 
-	textSize, _ := text.Measure(msg, &text.GoTextFace{
-		Source: mplusFaceSource,
-		Size:   24,
-	}, 24)
+func (g *game) addSkippable(item skippableItems) {
+	g.skipCord[item] = struct{}{}
+}
 
-	op := &text.DrawOptions{}
-	op.GeoM.Translate(float64(g.read.Rows()*g.cellSize)-textSize, 10)
-	op.ColorScale.ScaleWithColor(color.RGBA{255, 0, 0, 255})
-	text.Draw(screen, msg, &text.GoTextFace{
-		Source: mplusFaceSource,
-		Size:   24,
-	}, op)
+func (g *game) wipeSkippable() {
+	clear(g.skipCord)
 }
 
-func (g *game) Layout(outsideWidth, outsideHeight int) (int, int) {
-	return outsideWidth, outsideHeight
+// toolKeys binds keys to the editing tools.
+var toolKeys = map[ebiten.Key]tools.Kind{
+	ebiten.Key1: tools.Brush,
+	ebiten.Key2: tools.Line,
+	ebiten.Key3: tools.Rectangle,
+	ebiten.Key4: tools.FilledRectangle,
+	ebiten.Key5: tools.Selector,
+	ebiten.Key6: tools.Placer,
 }
 
+// handleClick feeds the mouse to the toolbox: the left button paints live
+// cells and the right button erases with the same tool.
 func (g *game) handleClick() {
 	mouseX, mouseY := ebiten.CursorPosition()
+	x, y := g.cam.Cell(mouseX, mouseY)
+	if g.unbounded() {
+		minX, minY, maxX, maxY := g.cam.Visible(g.screenW, g.screenH)
+		g.tools.SetView(minX, minY, maxX-minX, maxY-minY)
+	}
 
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		gridX := mouseX / g.cellSize
-		gridY := mouseY / g.cellSize
-
-		if gridX >= 0 && gridX < g.read.Cols() && gridY >= 0 && gridY < g.read.Rows() {
-			g.addSkippable(skippableItems{int16(gridY), int16(gridX)})
-			current := g.read.Coordinate(gridX, gridY) // Corrected: Coordinate(gridX, gridY)
-			val := !current
-			g.write.SetCoordinate(gridX, gridY, val) // Corrected: SetCoordinate(gridX, gridY)
-			g.read.SetCoordinate(gridX, gridY, val)  // Corrected: SetCoordinate(gridX, gridY)
+	if entry, ok := g.pickerHit(mouseX, mouseY); ok && !g.tools.Active() {
+		// A click on the picker chooses a pattern instead of stamping one.
+		if entry >= 0 && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			g.pick(entry)
 		}
+		return
+	}
+	if _, ok := g.timelineHit(mouseX, mouseY); (ok || g.scrubbing) && !g.tools.Active() {
+		// The timeline scrubs instead of drawing.
+		return
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
-		if g.stepEvery <= time.Millisecond*1000 {
-			g.stepEvery += time.Millisecond * 10
-		}
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		g.beginStroke()
+		g.toolButton = ebiten.MouseButtonLeft
+		g.tools.Press(x, y, true)
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight):
+		g.beginStroke()
+		g.toolButton = ebiten.MouseButtonRight
+		g.tools.Press(x, y, false)
+	case g.tools.Active() && ebiten.IsMouseButtonPressed(g.toolButton):
+		g.tools.Drag(x, y)
+	case g.tools.Active():
+		g.tools.Release(x, y)
 	}
 
-	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
-		if g.stepEvery >= time.Millisecond*10 {
-			g.stepEvery -= time.Millisecond * 10
+	for key, tool := range toolKeys {
+		if inpututil.IsKeyJustPressed(key) {
+			g.tools.Select(tool)
 		}
 	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyComma) {
+		g.tools.SetBrushSize(g.tools.BrushSize() - 1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPeriod) {
+		g.tools.SetBrushSize(g.tools.BrushSize() + 1)
+	}
+	if g.stroke && !g.tools.Active() {
+		// The gesture ended, by release or by switching tools.
+		g.undo.End()
+		g.stroke = false
+	}
 }
 
-func (g *game) step() {
-	// Apply Conway rules from read -> write, then copy back
-	for y := 0; y < g.read.Rows(); y++ {
-		for x := 0; x < g.read.Cols(); x++ { // Corrected: Iterate with y (row) then x (col)
-			skipStep := false
-			for _, items := range g.skipCord {
-				if items.row == int16(y) && items.col == int16(x) { // Corrected: check against y and x
-					skipStep = true
-					break
-				}
-			}
-
-			if skipStep {
-				continue
-			}
+// beginStroke groups everything the tool about to be pressed paints into one
+// undoable edit.
+func (g *game) beginStroke() {
+	if !g.stroke {
+		g.undo.Begin()
+		g.stroke = true
+	}
+}
 
-			alive := g.read.Coordinate(x, y)
-			neighbors := g.read.CountSurroundingLive(x, y)
+// paint is the toolbox's way of editing the board. Edited cells keep their
+// new value through the next step, cells off a fixed board are ignored, and
+// the edit can be undone, from a later generation by rewinding to it.
+func (g *game) paint(x, y int, alive bool) {
+	g.edits().SetCoordinate(x, y, alive)
+}
 
-			newVal := alive
-			if alive && neighbors < 2 {
-				newVal = false
-			}
-			if alive && (neighbors == 2 || neighbors == 3) {
-				newVal = true
-			}
-			if alive && neighbors > 3 {
-				newVal = false
-			}
-			if !alive && neighbors == 3 {
-				newVal = true
-			}
-			g.write.SetCoordinate(x, y, newVal) // Corrected: SetCoordinate(x, y)
+func (g *game) step() {
+	if g.unbounded() {
+		g.sparseStepper.Step(g.sparseRead, g.sparseWrite)
+		for item := range g.skipCord {
+			g.sparseWrite.SetCoordinate(item.col, item.row, g.sparseRead.Coordinate(item.col, item.row))
 		}
+		g.sparseRead, g.sparseWrite = g.sparseWrite, g.sparseRead
+		return
+	}
+
+	// Apply the configured rule from read -> write, then swap the buffers
+	g.stepper.Step(g.read, g.write)
+
+	// Cells clicked since the last step keep their new value for this generation.
+	for item := range g.skipCord {
+		g.write.SetCoordinate(item.col, item.row, g.read.Coordinate(item.col, item.row))
+		g.touch(item.col, item.row)
 	}
-	g.read.CopyBoard(g.write.FlatSlice())
+	g.read, g.write = g.write, g.read
 }
@@ -0,0 +1,128 @@
+package gameoflife
+
+import (
+	"SideProjectGames/gameoflife/internal/library"
+	"SideProjectGames/gameoflife/internal/tools"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// The picker lists the catalog down the left of the window while the
+	// Placer is selected, one pickerRowHeight row per category and pattern.
+	pickerX         = 10
+	pickerY         = 10
+	pickerWidth     = 220
+	pickerRowHeight = 20
+	pickerTextSize  = 16
+)
+
+var (
+	pickerBackground = color.RGBA{R: 20, G: 20, B: 20, A: 220}
+	pickerHeading    = color.RGBA{R: 160, G: 160, B: 160, A: 255}
+	pickerPicked     = color.RGBA{R: 0, G: 100, B: 160, A: 255}
+)
+
+// pickerRow is one line of the picker: a category heading when entry is -1,
+// otherwise the catalog entry it picks.
+type pickerRow struct {
+	label string
+	entry int
+}
+
+func (g *game) pickerRows() []pickerRow {
+	var rows []pickerRow
+	var last library.Category
+	for i := range g.library.Len() {
+		e := g.library.At(i)
+		if e.Category != last {
+			rows = append(rows, pickerRow{label: string(e.Category), entry: -1})
+			last = e.Category
+		}
+		rows = append(rows, pickerRow{label: e.Name(), entry: i})
+	}
+	return rows
+}
+
+func (g *game) pickerOpen() bool {
+	return g.tools.Tool() == tools.Placer
+}
+
+// pick makes catalog entry i, wrapping around, the pattern the Placer stamps.
+func (g *game) pick(i int) {
+	n := g.library.Len()
+	g.picked = (i%n + n) % n
+	g.tools.SetPattern(g.library.At(g.picked).Pattern.Region())
+}
+
+// pickerHit reports whether screen pixel (px, py) is on the open picker and
+// returns the catalog entry of the row there, or -1 on a category heading.
+func (g *game) pickerHit(px, py int) (int, bool) {
+	if !g.pickerOpen() || px < pickerX || px >= pickerX+pickerWidth || py < pickerY {
+		return 0, false
+	}
+	rows := g.pickerRows()
+	row := (py - pickerY) / pickerRowHeight
+	if row >= len(rows) {
+		return 0, false
+	}
+	return rows[row].entry, true
+}
+
+// handlePicker runs the picker keys while the Placer is selected: Tab and
+// Shift+Tab step through the catalog and O rotates the pattern clockwise.
+func (g *game) handlePicker() {
+	if !g.pickerOpen() {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+			g.pick(g.picked - 1)
+		} else {
+			g.pick(g.picked + 1)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyO) {
+		g.tools.RotatePattern()
+	}
+}
+
+// drawPicker draws the ghost of the pattern under the cursor and the
+// catalog list.
+func (g *game) drawPicker(screen *ebiten.Image) {
+	if !g.pickerOpen() {
+		return
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	if _, ok := g.pickerHit(mouseX, mouseY); !ok {
+		x, y := g.cam.Cell(mouseX, mouseY)
+		g.tools.Ghost(x, y, func(x, y int) {
+			g.fillCell(screen, x, y, preview)
+		})
+	}
+
+	rows := g.pickerRows()
+	vector.DrawFilledRect(screen, pickerX, pickerY, pickerWidth, float32(len(rows)*pickerRowHeight), pickerBackground, false)
+	face := &text.GoTextFace{Source: mplusFaceSource, Size: pickerTextSize}
+	for i, row := range rows {
+		y := float64(pickerY + i*pickerRowHeight)
+		op := &text.DrawOptions{}
+		switch {
+		case row.entry < 0:
+			op.GeoM.Translate(pickerX+4, y)
+			op.ColorScale.ScaleWithColor(pickerHeading)
+		case row.entry == g.picked:
+			vector.DrawFilledRect(screen, pickerX, float32(y), pickerWidth, pickerRowHeight, pickerPicked, false)
+			fallthrough
+		default:
+			op.GeoM.Translate(pickerX+16, y)
+			op.ColorScale.ScaleWithColor(white)
+		}
+		text.Draw(screen, row.label, face, op)
+	}
+}
@@ -0,0 +1,162 @@
+package gameoflife
+
+import (
+	"SideProjectGames/gameoflife/internal/engine"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// The timeline runs along the bottom of the window, timelineMargin in
+	// from each side, with its label above it.
+	timelineMargin   = 10
+	timelineHeight   = 12
+	timelineTextSize = 16
+)
+
+var (
+	timelineTrack  = color.RGBA{R: 40, G: 40, B: 40, A: 220}
+	timelinePlayed = color.RGBA{R: 0, G: 100, B: 160, A: 220}
+)
+
+// liveCells lists the current generation's live cells, or returns false for
+// a board too big to list every generation.
+func (g *game) liveCells() ([]engine.Cell, bool) {
+	switch {
+	case g.unbounded() && g.sparseRead.Population() <= maxDetectCells:
+		return engine.SparseLiveCells(g.sparseRead), true
+	case !g.unbounded() && g.read.Cols()*g.read.Rows() <= maxDetectCells:
+		return engine.LiveCells(g.read), true
+	}
+	return nil, false
+}
+
+// remember records the board in the history as generation gen, usually the
+// current one. Recording a generation the history already has, after
+// rewinding or editing, branches: the generations after it are dropped.
+func (g *game) remember(gen int64) {
+	g.historyDirty = false
+	if cells, ok := g.liveCells(); ok {
+		g.history.Record(gen, cells)
+	}
+}
+
+// rewind puts the board back to a stored generation and pauses there. The
+// edits made since belong to generations about to be dropped, so they can no
+// longer be undone.
+func (g *game) rewind(gen int64) {
+	if g.restore(gen) {
+		g.undo.Clear()
+	}
+}
+
+// restore puts the board back to a stored generation and pauses there,
+// reporting false when the history no longer has it.
+func (g *game) restore(gen int64) bool {
+	cells, ok := g.history.At(gen)
+	if !ok {
+		return false
+	}
+	if g.unbounded() {
+		g.sparseRead.Clear()
+		for _, c := range cells {
+			g.sparseRead.SetCoordinate(c.X, c.Y, true)
+		}
+	} else {
+		flat := make([]bool, g.read.Cols()*g.read.Rows())
+		for _, c := range cells {
+			flat[c.Y*g.read.Cols()+c.X] = true
+		}
+		g.read.CopyBoard(flat)
+	}
+	g.run.JumpTo(gen)
+
+	// Like a reset, the board changed wholesale; the history itself still
+	// holds, so it is not marked dirty.
+	g.wipeSkippable()
+	g.detector.Reset()
+	g.objects = ""
+	if tracker, ok := g.stepper.(engine.ChangeTracker); ok {
+		tracker.Reset()
+	}
+	return true
+}
+
+// timelineRect is where the timeline is drawn.
+func (g *game) timelineRect() (x, y, width, height float32) {
+	return timelineMargin, float32(g.screenH - timelineMargin - timelineHeight), float32(g.screenW - 2*timelineMargin), timelineHeight
+}
+
+// timelineHit reports whether the point is on the timeline and, if so, the
+// stored generation under it.
+func (g *game) timelineHit(px, py int) (int64, bool) {
+	if g.history.Len() < 2 {
+		return 0, false
+	}
+	x, y, width, height := g.timelineRect()
+	if float32(px) < x || float32(px) > x+width || float32(py) < y || float32(py) > y+height {
+		return 0, false
+	}
+	return g.timelineGeneration(px), true
+}
+
+// timelineGeneration maps an x coordinate to the nearest stored generation.
+func (g *game) timelineGeneration(px int) int64 {
+	x, _, width, _ := g.timelineRect()
+	span := float32(g.history.Newest() - g.history.Oldest())
+	offset := int64((float32(px)-x)/width*span + 0.5)
+	return g.history.Oldest() + min(max(offset, 0), int64(span))
+}
+
+// handleTimeline steps back a generation with B and scrubs with a drag on
+// the timeline. Stepping or resuming from a past generation branches the
+// history there.
+func (g *game) handleTimeline() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) && !ctrlHeld() {
+		if g.historyDirty {
+			g.remember(g.run.Generation())
+		}
+		g.rewind(g.run.Generation() - 1)
+	}
+
+	mouseX, mouseY := ebiten.CursorPosition()
+	if _, ok := g.timelineHit(mouseX, mouseY); ok && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && !g.tools.Active() {
+		if g.historyDirty {
+			g.remember(g.run.Generation())
+		}
+		g.scrubbing = true
+	}
+	if !g.scrubbing {
+		return
+	}
+	if gen := g.timelineGeneration(mouseX); gen != g.run.Generation() {
+		g.rewind(gen)
+	}
+	g.scrubbing = ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+}
+
+// drawTimeline draws the stored generations as a bar, filled up to the
+// current one, once there is more than one to choose from.
+func (g *game) drawTimeline(screen *ebiten.Image) {
+	if g.history.Len() < 2 {
+		return
+	}
+	x, y, width, height := g.timelineRect()
+	oldest, newest, current := g.history.Oldest(), g.history.Newest(), g.run.Generation()
+	played := width * float32(min(max(current-oldest, 0), newest-oldest)) / float32(newest-oldest)
+
+	vector.DrawFilledRect(screen, x, y, width, height, timelineTrack, false)
+	vector.DrawFilledRect(screen, x, y, played, height, timelinePlayed, false)
+	vector.DrawFilledRect(screen, x+played-2, y-2, 4, height+4, white, false)
+
+	msg := fmt.Sprintf("History: gen %d-%d (B: back, drag to scrub)", oldest, newest)
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(y)-timelineTextSize-6)
+	op.ColorScale.ScaleWithColor(white)
+	text.Draw(screen, msg, &text.GoTextFace{Source: mplusFaceSource, Size: timelineTextSize}, op)
+}
@@ -0,0 +1,259 @@
+package gameoflife
+
+import (
+	"SideProjectGames/gameoflife/internal/camera"
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	// initialZoom is the cell size in pixels the window opens with.
+	initialZoom     = 10
+	maxWindowWidth  = 1280
+	maxWindowHeight = 960
+	// panSpeed is how far the arrow keys move the view each frame, in pixels.
+	panSpeed = 8
+	// wheelZoom is the zoom factor for one notch of the mouse wheel.
+	wheelZoom = 1.1
+)
+
+var (
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	edge  = color.RGBA{R: 80, G: 80, B: 80, A: 255}
+	// preview tints the cells a pending line or rectangle will change.
+	preview = color.RGBA{R: 0, G: 100, B: 160, A: 160}
+	// selected outlines the selection.
+	selected = color.RGBA{R: 255, G: 200, B: 0, A: 255}
+)
+
+// centerCamera puts the middle of the live cells in the middle of the view.
+func (g *game) centerCamera() {
+	minX, minY, maxX, maxY, ok := g.sparseRead.Bounds()
+	if !ok {
+		return
+	}
+	g.cam.CenterOn(float64(minX+maxX+1)/2, float64(minY+maxY+1)/2, g.screenW, g.screenH)
+}
+
+// fitView zooms to show the whole board, or when unbounded all live cells.
+func (g *game) fitView() {
+	if !g.unbounded() {
+		g.cam.Fit(0, 0, float64(g.read.Cols()), float64(g.read.Rows()), g.screenW, g.screenH)
+		return
+	}
+	minX, minY, maxX, maxY, ok := g.sparseRead.Bounds()
+	if !ok {
+		return
+	}
+	g.cam.Fit(float64(minX), float64(minY), float64(maxX-minX+1), float64(maxY-minY+1), g.screenW, g.screenH)
+}
+
+// handleCamera pans and zooms (see moveCamera), fits with F and, when
+// unbounded, re-centres on the live cells with H.
+func (g *game) handleCamera() {
+	moveCamera(g.cam, g.screenW, g.screenH, &g.drag)
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		g.fitView()
+	}
+	if g.unbounded() && inpututil.IsKeyJustPressed(ebiten.KeyH) {
+		g.centerCamera()
+	}
+}
+
+// mouseDrag follows a middle-button drag from frame to frame.
+type mouseDrag struct {
+	active bool
+	x, y   int
+}
+
+// moveCamera pans cam with the arrow keys or a middle-button drag and zooms
+// it with the mouse wheel (around the cursor) or -/=.
+func moveCamera(cam camera.Camera, screenW, screenH int, drag *mouseDrag) {
+	mouseX, mouseY := ebiten.CursorPosition()
+
+	if ebiten.IsKeyPressed(ebiten.KeyLeft) {
+		cam.Pan(panSpeed, 0)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyRight) {
+		cam.Pan(-panSpeed, 0)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyUp) {
+		cam.Pan(0, panSpeed)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyDown) {
+		cam.Pan(0, -panSpeed)
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) {
+		drag.active, drag.x, drag.y = true, mouseX, mouseY
+	}
+	if drag.active {
+		cam.Pan(float64(mouseX-drag.x), float64(mouseY-drag.y))
+		drag.x, drag.y = mouseX, mouseY
+		drag.active = ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle)
+	}
+
+	if _, wheel := ebiten.Wheel(); wheel != 0 {
+		cam.ZoomAt(float64(mouseX), float64(mouseY), math.Pow(wheelZoom, wheel))
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		cam.ZoomAt(float64(screenW)/2, float64(screenH)/2, 2)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		cam.ZoomAt(float64(screenW)/2, float64(screenH)/2, 0.5)
+	}
+}
+
+func (g *game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if outsideWidth != g.screenW || outsideHeight != g.screenH {
+		g.cam.Resize(g.screenW, g.screenH, outsideWidth, outsideHeight)
+		g.screenW, g.screenH = outsideWidth, outsideHeight
+	}
+	return outsideWidth, outsideHeight
+}
+
+func (g *game) Draw(screen *ebiten.Image) {
+	// Clear
+	screen.Fill(color.RGBA{A: 255})
+
+	minX, minY, maxX, maxY := g.cam.Visible(g.screenW, g.screenH)
+	if g.unbounded() {
+		// Only the live cells are visited; the ones off screen are skipped.
+		g.sparseRead.EachLive(func(x, y int) {
+			if x >= minX && x < maxX && y >= minY && y < maxY {
+				g.drawCell(screen, x, y)
+			}
+		})
+	} else {
+		if g.cam.Zoom() < 1 {
+			// Several cells share each pixel, so sample one cell per pixel
+			// rather than drawing every cell of a huge board.
+			g.drawSampled(screen)
+		} else {
+			// Only the cells on screen are visited.
+			for y := max(minY, 0); y < min(maxY, g.read.Rows()); y++ {
+				for x := max(minX, 0); x < min(maxX, g.read.Cols()); x++ {
+					if g.read.Coordinate(x, y) {
+						g.drawCell(screen, x, y)
+					}
+				}
+			}
+		}
+		x0, y0 := g.cam.WorldToScreen(0, 0)
+		x1, y1 := g.cam.WorldToScreen(float64(g.read.Cols()), float64(g.read.Rows()))
+		vector.StrokeRect(screen, float32(x0), float32(y0), float32(x1-x0), float32(y1-y0), 1, edge, false)
+	}
+
+	g.tools.Preview(func(x, y, width, height int) {
+		g.fillBlock(screen, x, y, width, height, preview)
+	})
+	if x, y, width, height, ok := g.tools.Selection(); ok {
+		x0, y0 := g.cam.WorldToScreen(float64(x), float64(y))
+		x1, y1 := g.cam.WorldToScreen(float64(x+width), float64(y+height))
+		vector.StrokeRect(screen, float32(x0), float32(y0), float32(x1-x0), float32(y1-y0), 2, selected, false)
+	}
+
+	g.drawPicker(screen)
+	g.drawTimeline(screen)
+	g.drawHUD(screen, minX, minY)
+}
+
+// drawCell draws one live cell as a white square, leaving a one pixel gap
+// between cells once they are big enough to tell apart.
+func (g *game) drawCell(screen *ebiten.Image, x, y int) {
+	g.fillCell(screen, x, y, white)
+}
+
+func (g *game) fillCell(screen *ebiten.Image, x, y int, c color.Color) {
+	g.fillBlock(screen, x, y, 1, 1, c)
+}
+
+// fillBlock fills the width x height block of cells at (x, y), keeping the
+// gap fillCell leaves after its last column and row.
+func (g *game) fillBlock(screen *ebiten.Image, x, y, width, height int, c color.Color) {
+	px, py := g.cam.WorldToScreen(float64(x), float64(y))
+	zoom := g.cam.Zoom()
+	w, h := float64(width)*zoom, float64(height)*zoom
+	if zoom >= 4 {
+		w, h = w-1, h-1
+	}
+	vector.DrawFilledRect(screen, float32(px), float32(py), float32(max(w, 1)), float32(max(h, 1)), c, false)
+}
+
+// drawSampled renders the fixed board one screen pixel at a time.
+func (g *game) drawSampled(screen *ebiten.Image) {
+	w, h := g.screenW, g.screenH
+	if w <= 0 || h <= 0 {
+		return
+	}
+	if g.canvas == nil || g.canvas.Bounds().Dx() != w || g.canvas.Bounds().Dy() != h {
+		g.canvas = ebiten.NewImage(w, h)
+		g.pixels = make([]byte, w*h*4)
+	}
+
+	cols := make([]int, w)
+	for px := range cols {
+		cols[px], _ = g.cam.Cell(px, 0)
+	}
+	clear(g.pixels)
+	for py := 0; py < h; py++ {
+		_, y := g.cam.Cell(0, py)
+		if y < 0 || y >= g.read.Rows() {
+			continue
+		}
+		for px, x := range cols {
+			if x >= 0 && x < g.read.Cols() && g.read.Coordinate(x, y) {
+				i := (py*w + px) * 4
+				g.pixels[i], g.pixels[i+1], g.pixels[i+2], g.pixels[i+3] = 255, 255, 255, 255
+			}
+		}
+	}
+	g.canvas.WritePixels(g.pixels)
+	screen.DrawImage(g.canvas, nil)
+}
+
+func (g *game) drawHUD(screen *ebiten.Image, viewX, viewY int) {
+	msg := fmt.Sprintf("Seed: %d  Rule: %s  Step Time: %v  %s  Gen: %d  Zoom: %.3gx  Tool: %s  Brush: %d",
+		g.rngSeed, g.ruleName(), g.run.Interval(), g.run.State(), g.run.Generation(), g.cam.Zoom(), g.tools.Tool(), g.tools.BrushSize())
+	if g.unbounded() {
+		msg = fmt.Sprintf("Population: %d  View: (%d, %d)  %s", g.sparseRead.Population(), viewX, viewY, msg)
+	}
+
+	if g.message != "" {
+		msg = g.message + "  " + msg
+	}
+
+	g.drawHUDLine(screen, msg, 10)
+
+	// A second line says what the board settled into, once it has.
+	v := g.detector.Verdict()
+	if !v.Settled() {
+		return
+	}
+	msg = fmt.Sprintf("Settled at gen %d: %s", v.Start, v)
+	if g.objects != "" {
+		msg += "  Objects: " + g.objects
+	}
+	g.drawHUDLine(screen, msg, 40)
+}
+
+// drawHUDLine draws msg right-aligned at height y.
+func (g *game) drawHUDLine(screen *ebiten.Image, msg string, y float64) {
+	face := &text.GoTextFace{
+		Source: mplusFaceSource,
+		Size:   24,
+	}
+	textSize, _ := text.Measure(msg, face, 24)
+
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(g.screenW)-textSize, y)
+	op.ColorScale.ScaleWithColor(color.RGBA{255, 0, 0, 255})
+	text.Draw(screen, msg, face, op)
+}
@@ -0,0 +1,102 @@
+// Package clipboard moves text between the games and other programs through
+// the operating system clipboard. Ebiten has no clipboard API, so the system
+// clipboard is reached through the usual command line tools; when none is
+// available the clipboard only lives inside the running process.
+package clipboard
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Clipboard holds one piece of text.
+type Clipboard interface {
+	ReadText() (string, error)
+	WriteText(text string) error
+}
+
+type memory struct {
+	text string
+}
+
+var _ Clipboard = (*memory)(nil)
+
+// NewMemory returns a clipboard private to this process.
+func NewMemory() Clipboard {
+	return &memory{}
+}
+
+func (m *memory) ReadText() (string, error) {
+	return m.text, nil
+}
+
+func (m *memory) WriteText(text string) error {
+	m.text = text
+	return nil
+}
+
+// system runs a copy and a paste command. Text is also kept in memory so
+// the process can read back what it wrote when the commands fail, for
+// example without a display.
+type system struct {
+	copyCmd  []string
+	pasteCmd []string
+	memory
+}
+
+var _ Clipboard = (*system)(nil)
+
+// New returns the operating system clipboard, or an in-process one when no
+// clipboard tool is installed.
+func New() Clipboard {
+	for _, tool := range tools() {
+		if _, err := exec.LookPath(tool[0][0]); err != nil {
+			continue
+		}
+		if _, err := exec.LookPath(tool[1][0]); err != nil {
+			continue
+		}
+		return newSystem(tool[0], tool[1])
+	}
+	return NewMemory()
+}
+
+func newSystem(copyCmd, pasteCmd []string) *system {
+	return &system{copyCmd: copyCmd, pasteCmd: pasteCmd}
+}
+
+// tools lists the copy and paste commands to try on this platform, in order.
+func tools() [][2][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][2][]string{{{"pbcopy"}, {"pbpaste"}}}
+	case "windows":
+		return [][2][]string{{{"clip.exe"}, {"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard -Raw"}}}
+	}
+	var found [][2][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		found = append(found, [2][]string{{"wl-copy"}, {"wl-paste", "--no-newline"}})
+	}
+	return append(found,
+		[2][]string{{"xclip", "-selection", "clipboard"}, {"xclip", "-selection", "clipboard", "-o"}},
+		[2][]string{{"xsel", "--clipboard", "--input"}, {"xsel", "--clipboard", "--output"}},
+	)
+}
+
+func (s *system) ReadText() (string, error) {
+	out, err := exec.Command(s.pasteCmd[0], s.pasteCmd[1:]...).Output()
+	if err != nil {
+		return s.memory.ReadText()
+	}
+	return strings.ReplaceAll(string(out), "\r\n", "\n"), nil
+}
+
+func (s *system) WriteText(text string) error {
+	s.memory.WriteText(text)
+	cmd := exec.Command(s.copyCmd[0], s.copyCmd[1:]...)
+	cmd.Stdin = bytes.NewBufferString(text)
+	return cmd.Run()
+}
@@ -0,0 +1,42 @@
+package clipboard
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMemory_RoundTrip(t *testing.T) {
+	c := NewMemory()
+	c.WriteText("x = 3, y = 1\n3o!")
+	if got, _ := c.ReadText(); got != "x = 3, y = 1\n3o!" {
+		t.Errorf("Expected the written text back, but got %q", got)
+	}
+}
+
+func TestSystem_UsesTheCommands(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	file := filepath.Join(t.TempDir(), "clip")
+	c := newSystem([]string{"sh", "-c", "cat > " + file}, []string{"cat", file})
+
+	if err := c.WriteText("bo$2bo$3o!"); err != nil {
+		t.Fatalf("Expected the copy command to succeed, but got %v", err)
+	}
+	c.memory.text = ""
+	if got, _ := c.ReadText(); got != "bo$2bo$3o!" {
+		t.Errorf("Expected the paste command to return the text, but got %q", got)
+	}
+}
+
+func TestSystem_FallsBackToMemory(t *testing.T) {
+	c := newSystem([]string{"no-such-clipboard-tool"}, []string{"no-such-clipboard-tool"})
+
+	if err := c.WriteText("3o!"); err == nil {
+		t.Error("Expected a missing copy command to be reported, but got nil")
+	}
+	if got, err := c.ReadText(); err != nil || got != "3o!" {
+		t.Errorf("Expected the text from memory, but got %q, %v", got, err)
+	}
+}
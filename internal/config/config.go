@@ -1,25 +1,67 @@
 package config
 
 import (
+	"SideProjectGames/internal/rng"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/stackus/dotenv"
 )
 
 type AppConfig struct {
-	GOLWIDTH         int
-	GOLHEIGHT        int
-	BATTLESHIPWIDTH  int
-	BATTLESHIPHEIGHT int
+	MODULE           string
+	SEED             int64
+	GOLWIDTH         int    `default:"80"`
+	GOLHEIGHT        int    `default:"60"`
+	EDGEMODE         string `envconfig:"EDGE_MODE" default:"TOROIDAL"`
+	GOLRULE          string
+	GOLPATTERN       string
+	GOLBOARD         string `default:"BOOL"`
+	GOLSTEPPER       string `default:"ACTIVE"`
+	GOLWORKERS       int
+	GOLDENSITY       float64 `default:"0.5"`
+	GOLSEEDREGION    string
+	GOLSYMMETRY      string `default:"NONE"`
+	GOLEXPORTDIR     string `default:"."`
+	GOLEXPORTCELL    int    `default:"4"`
+	GOLEXPORTGRID    bool   `default:"false"`
+	BATTLESHIPWIDTH  int    `default:"10"`
+	BATTLESHIPHEIGHT int    `default:"10"`
 }
 
-func InitConfig() (cfg AppConfig, err error) {
+// InitConfig loads the configuration from the environment (and optional .env files).
+// When modules is non-empty, a set MODULE must name one of them; the comparison is
+// case-insensitive and MODULE is normalized to upper case. An empty MODULE means
+// "show the main menu". An unset (or zero) SEED is replaced by one picked from
+// the clock, so every run has a seed that can be shown and reused.
+func InitConfig(modules ...string) (cfg AppConfig, err error) {
 	if err = dotenv.Load(dotenv.EnvironmentFiles(os.Getenv("ENVIRONMENT"))); err != nil {
 		return
 	}
 
-	err = envconfig.Process("", &cfg)
+	if err = envconfig.Process("", &cfg); err != nil {
+		return
+	}
+
+	cfg.MODULE = strings.ToUpper(strings.TrimSpace(cfg.MODULE))
+	if cfg.SEED == 0 {
+		cfg.SEED = rng.NewSeed()
+	}
+	err = validateModule(cfg.MODULE, modules)
 
 	return
 }
+
+func validateModule(name string, modules []string) error {
+	if name == "" || len(modules) == 0 {
+		return nil
+	}
+	for _, m := range modules {
+		if strings.EqualFold(m, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown MODULE %q; available modules: %s", name, strings.Join(modules, ", "))
+}
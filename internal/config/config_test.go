@@ -0,0 +1,30 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateModule(t *testing.T) {
+	modules := []string{"BATTLESHIP", "GOL"}
+
+	if err := validateModule("GOL", modules); err != nil {
+		t.Errorf("Expected GOL to be valid, but got %v", err)
+	}
+
+	err := validateModule("CHESS", modules)
+	if err == nil {
+		t.Fatal("Expected an error for an unknown module, but got nil")
+	}
+	if !strings.Contains(err.Error(), "BATTLESHIP, GOL") {
+		t.Errorf("Expected error to list the available modules, but got %q", err.Error())
+	}
+
+	if err := validateModule("", modules); err != nil {
+		t.Errorf("Expected an empty MODULE to select the menu, but got %v", err)
+	}
+
+	if err := validateModule("ANYTHING", nil); err != nil {
+		t.Errorf("Expected no validation without a module list, but got %v", err)
+	}
+}
@@ -1,36 +1,73 @@
 package ddd
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
-type Board[T any] interface {
+// ErrOutOfBounds is returned when writing outside a Bounded board.
+var ErrOutOfBounds = errors.New("coordinate out of bounds")
+
+// Grid is plain cell access, shared by fixed-size boards and unbounded ones.
+type Grid[T any] interface {
 	Coordinate(x int, y int) T
-	SetCoordinate(x int, y int, value T)
+	SetCoordinate(x int, y int, value T) error
+}
+
+type Board[T any] interface {
+	Grid[T]
 	CopyBoard(flatSlice []T)
 	PrintBoard()
 	FlatSlice() []T
 	Rows() int
 	Cols() int
+	Topology() Topology
+	InBounds(x int, y int) bool
 }
 
 type board[T any] struct {
 	flatSlice []T
 	cols      int
 	rows      int
+	topology  Topology
+	outside   T
 	Board[T]
 }
 
 var _ Board[int] = (*board[int])(nil)
 
-func NewBoard[T any](width int, height int) Board[T] {
-	return newBoard[T](width, height)
+// Option configures a board created by NewBoard.
+type Option[T any] func(*board[T])
+
+// WithTopology selects how coordinates past the edges are treated. The default is Toroidal.
+func WithTopology[T any](topology Topology) Option[T] {
+	return func(b *board[T]) {
+		b.topology = topology
+	}
+}
+
+// WithOutside sets the value a Bounded board returns for reads past its edges.
+// The default is the zero value of T.
+func WithOutside[T any](value T) Option[T] {
+	return func(b *board[T]) {
+		b.outside = value
+	}
 }
 
-func newBoard[T any](width int, height int) Board[T] {
-	return &board[T]{
+func NewBoard[T any](width int, height int, opts ...Option[T]) Board[T] {
+	return newBoard[T](width, height, opts...)
+}
+
+func newBoard[T any](width int, height int, opts ...Option[T]) Board[T] {
+	b := &board[T]{
 		rows:      height,
 		cols:      width,
 		flatSlice: make([]T, width*height),
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 func (b *board[T]) CopyBoard(writeSlice []T) {
@@ -38,17 +75,22 @@ func (b *board[T]) CopyBoard(writeSlice []T) {
 }
 
 func (b *board[T]) Coordinate(x int, y int) T {
-	row := (y + b.rows) % b.rows
-	col := (x + b.cols) % b.cols
+	col, row, ok := b.topology.Resolve(x, y, b.cols, b.rows)
+	if !ok {
+		return b.outside
+	}
 
 	return b.flatSlice[(row*b.cols)+col]
 }
 
-func (b *board[T]) SetCoordinate(x int, y int, value T) {
-	row := (y + b.rows) % b.rows
-	col := (x + b.cols) % b.cols
+func (b *board[T]) SetCoordinate(x int, y int, value T) error {
+	col, row, ok := b.topology.Resolve(x, y, b.cols, b.rows)
+	if !ok {
+		return fmt.Errorf("%w: (%d, %d) on a %dx%d board", ErrOutOfBounds, x, y, b.cols, b.rows)
+	}
 
 	b.flatSlice[(row*b.cols)+col] = value
+	return nil
 }
 
 func (b *board[T]) PrintBoard() {
@@ -71,3 +113,12 @@ func (b *board[T]) Cols() int {
 func (b *board[T]) Rows() int {
 	return b.rows
 }
+
+func (b *board[T]) Topology() Topology {
+	return b.topology
+}
+
+// InBounds reports whether (x, y) lies on the board without any wrapping.
+func (b *board[T]) InBounds(x int, y int) bool {
+	return x >= 0 && x < b.cols && y >= 0 && y < b.rows
+}
@@ -0,0 +1,106 @@
+package ddd
+
+import (
+	"errors"
+	"testing"
+)
+
+// numberedBoard returns a cols x rows board where each cell holds its flat index.
+func numberedBoard(cols, rows int, opts ...Option[int]) Board[int] {
+	b := newBoard[int](cols, rows, opts...)
+	for i := range b.FlatSlice() {
+		b.FlatSlice()[i] = i
+	}
+	return b
+}
+
+func TestBoard_ToroidalWraps(t *testing.T) {
+	b := numberedBoard(4, 3)
+
+	if got := b.Coordinate(-1, 0); got != 3 {
+		t.Errorf("Expected (-1, 0) to wrap to 3, but got %d", got)
+	}
+	if got := b.Coordinate(0, -1); got != 8 {
+		t.Errorf("Expected (0, -1) to wrap to 8, but got %d", got)
+	}
+	if got := b.Coordinate(-9, 7); got != 7 {
+		t.Errorf("Expected (-9, 7) to wrap to 7, but got %d", got)
+	}
+}
+
+func TestBoard_BoundedReadsOutsideValue(t *testing.T) {
+	b := numberedBoard(4, 3, WithTopology[int](Bounded), WithOutside(-1))
+
+	if got := b.Coordinate(-1, 0); got != -1 {
+		t.Errorf("Expected outside value -1, but got %d", got)
+	}
+	if got := b.Coordinate(4, 2); got != -1 {
+		t.Errorf("Expected outside value -1, but got %d", got)
+	}
+	if got := b.Coordinate(3, 2); got != 11 {
+		t.Errorf("Expected in-range read of 11, but got %d", got)
+	}
+}
+
+func TestBoard_BoundedRejectsWritesOutside(t *testing.T) {
+	b := newBoard[int](4, 3, WithTopology[int](Bounded))
+
+	err := b.SetCoordinate(4, 0, 9)
+	if !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected ErrOutOfBounds, but got %v", err)
+	}
+	for _, v := range b.FlatSlice() {
+		if v != 0 {
+			t.Fatalf("Expected a rejected write to leave the board untouched, but got %v", b.FlatSlice())
+		}
+	}
+
+	if err := b.SetCoordinate(3, 2, 9); err != nil {
+		t.Errorf("Expected no error for an in-range write, but got %v", err)
+	}
+}
+
+func TestBoard_KleinBottleFlipsAcrossVerticalSeam(t *testing.T) {
+	b := numberedBoard(4, 3, WithTopology[int](KleinBottle))
+
+	// Stepping off the right edge of row 0 lands on the left edge of row 2.
+	if got := b.Coordinate(4, 0); got != 8 {
+		t.Errorf("Expected (4, 0) to map to 8, but got %d", got)
+	}
+	// The top/bottom seam is a plain wrap.
+	if got := b.Coordinate(1, 3); got != 1 {
+		t.Errorf("Expected (1, 3) to map to 1, but got %d", got)
+	}
+}
+
+func TestBoard_ProjectivePlaneFlipsBothSeams(t *testing.T) {
+	b := numberedBoard(4, 3, WithTopology[int](ProjectivePlane))
+
+	if got := b.Coordinate(4, 0); got != 8 {
+		t.Errorf("Expected (4, 0) to map to 8, but got %d", got)
+	}
+	// Stepping off the bottom of column 1 lands on the top of column 2.
+	if got := b.Coordinate(1, 3); got != 2 {
+		t.Errorf("Expected (1, 3) to map to 2, but got %d", got)
+	}
+}
+
+func TestParseTopology(t *testing.T) {
+	cases := map[string]Topology{
+		"":                 Toroidal,
+		"toroidal":         Toroidal,
+		"BOUNDED":          Bounded,
+		"klein_bottle":     KleinBottle,
+		"Projective-Plane": ProjectivePlane,
+	}
+	for in, want := range cases {
+		got, err := ParseTopology(in)
+		if err != nil || got != want {
+			t.Errorf("ParseTopology(%q) = %v, %v; expected %v", in, got, err, want)
+		}
+	}
+
+	if _, err := ParseTopology("sphere"); err == nil {
+		t.Error("Expected an error for an unknown edge mode, but got nil")
+	}
+}
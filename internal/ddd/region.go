@@ -0,0 +1,90 @@
+package ddd
+
+// Region is a rectangular block of cells lifted off a board, for copying,
+// transforming and stamping back. Cells is row-major, Width*Height long.
+type Region[T any] struct {
+	Width  int
+	Height int
+	Cells  []T
+}
+
+// NewRegion returns a width x height region of zero values.
+func NewRegion[T any](width int, height int) *Region[T] {
+	return &Region[T]{Width: width, Height: height, Cells: make([]T, width*height)}
+}
+
+// At returns the cell at (x, y) of the region.
+func (r *Region[T]) At(x int, y int) T {
+	return r.Cells[y*r.Width+x]
+}
+
+// Set changes the cell at (x, y) of the region.
+func (r *Region[T]) Set(x int, y int, value T) {
+	r.Cells[y*r.Width+x] = value
+}
+
+// Extract copies the width x height block of g whose top-left corner is at
+// (x, y). Coordinates follow the grid's own edge rules.
+func Extract[T any](g Grid[T], x int, y int, width int, height int) *Region[T] {
+	r := NewRegion[T](width, height)
+	for dy := 0; dy < height; dy++ {
+		for dx := 0; dx < width; dx++ {
+			r.Set(dx, dy, g.Coordinate(x+dx, y+dy))
+		}
+	}
+	return r
+}
+
+// Stamp writes the region onto g with its top-left corner at (x, y). When
+// keep is non-nil only the cells it accepts are written, so for example dead
+// cells can leave the board untouched. Every cell that can be written is; the
+// first error, such as ErrOutOfBounds past the edge of a Bounded board, is
+// returned.
+func Stamp[T any](g Grid[T], r *Region[T], x int, y int, keep func(T) bool) error {
+	var first error
+	for dy := 0; dy < r.Height; dy++ {
+		for dx := 0; dx < r.Width; dx++ {
+			value := r.At(dx, dy)
+			if keep != nil && !keep(value) {
+				continue
+			}
+			if err := g.SetCoordinate(x+dx, y+dy, value); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// Rotate returns the region turned 90 degrees clockwise.
+func (r *Region[T]) Rotate() *Region[T] {
+	out := NewRegion[T](r.Height, r.Width)
+	for y := 0; y < r.Height; y++ {
+		for x := 0; x < r.Width; x++ {
+			out.Set(r.Height-1-y, x, r.At(x, y))
+		}
+	}
+	return out
+}
+
+// FlipHorizontal returns the region mirrored left to right.
+func (r *Region[T]) FlipHorizontal() *Region[T] {
+	out := NewRegion[T](r.Width, r.Height)
+	for y := 0; y < r.Height; y++ {
+		for x := 0; x < r.Width; x++ {
+			out.Set(r.Width-1-x, y, r.At(x, y))
+		}
+	}
+	return out
+}
+
+// FlipVertical returns the region mirrored top to bottom.
+func (r *Region[T]) FlipVertical() *Region[T] {
+	out := NewRegion[T](r.Width, r.Height)
+	for y := 0; y < r.Height; y++ {
+		for x := 0; x < r.Width; x++ {
+			out.Set(x, r.Height-1-y, r.At(x, y))
+		}
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package ddd
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestExtract_FollowsTopology(t *testing.T) {
+	b := numberedBoard(4, 3)
+
+	r := Extract[int](b, 3, 2, 2, 2)
+	if want := []int{11, 8, 3, 0}; !slices.Equal(r.Cells, want) {
+		t.Errorf("Expected %v across the wrapped corner, but got %v", want, r.Cells)
+	}
+}
+
+func TestRegion_Transforms(t *testing.T) {
+	// 0 1 2
+	// 3 4 5
+	r := &Region[int]{Width: 3, Height: 2, Cells: []int{0, 1, 2, 3, 4, 5}}
+
+	rotated := r.Rotate()
+	if rotated.Width != 2 || rotated.Height != 3 || !slices.Equal(rotated.Cells, []int{3, 0, 4, 1, 5, 2}) {
+		t.Errorf("Expected a clockwise turn, but got %dx%d %v", rotated.Width, rotated.Height, rotated.Cells)
+	}
+	if full := r.Rotate().Rotate().Rotate().Rotate(); !slices.Equal(full.Cells, r.Cells) {
+		t.Errorf("Expected four turns to restore the region, but got %v", full.Cells)
+	}
+	if got := r.FlipHorizontal().Cells; !slices.Equal(got, []int{2, 1, 0, 5, 4, 3}) {
+		t.Errorf("Expected a left-right mirror, but got %v", got)
+	}
+	if got := r.FlipVertical().Cells; !slices.Equal(got, []int{3, 4, 5, 0, 1, 2}) {
+		t.Errorf("Expected a top-bottom mirror, but got %v", got)
+	}
+}
+
+func TestStamp_KeepAndBounds(t *testing.T) {
+	b := newBoard[int](4, 3, WithTopology[int](Bounded))
+	r := &Region[int]{Width: 2, Height: 2, Cells: []int{1, 0, 0, 2}}
+
+	if err := Stamp[int](b, r, 0, 0, func(v int) bool { return v != 0 }); err != nil {
+		t.Fatalf("Expected the stamp to fit, but got %v", err)
+	}
+	if !slices.Equal(b.FlatSlice(), []int{1, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0}) {
+		t.Errorf("Expected only the non-zero cells to be written, but got %v", b.FlatSlice())
+	}
+
+	err := Stamp[int](b, r, 3, 2, nil)
+	if !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected ErrOutOfBounds, but got %v", err)
+	}
+	if b.Coordinate(3, 2) != 1 {
+		t.Error("Expected the cells that fit to be written anyway")
+	}
+}
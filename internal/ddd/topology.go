@@ -0,0 +1,100 @@
+package ddd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Topology decides what happens when a coordinate falls off the edge of a board.
+type Topology uint8
+
+const (
+	// Toroidal wraps both axes, so the board is the surface of a torus.
+	Toroidal Topology = iota
+	// Bounded has hard edges: reads outside return the board's outside value
+	// and writes outside are rejected.
+	Bounded
+	// KleinBottle wraps both axes, mirroring rows when crossing the left/right edge.
+	KleinBottle
+	// ProjectivePlane wraps both axes, mirroring the other axis on every crossing.
+	ProjectivePlane
+)
+
+var topologyNames = map[Topology]string{
+	Toroidal:        "TOROIDAL",
+	Bounded:         "BOUNDED",
+	KleinBottle:     "KLEIN",
+	ProjectivePlane: "PROJECTIVE",
+}
+
+func (t Topology) String() string {
+	if name, ok := topologyNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Topology(%d)", uint8(t))
+}
+
+// ParseTopology converts a config value such as "TOROIDAL", "bounded",
+// "klein_bottle" or "projective-plane" into a Topology. An empty string is Toroidal.
+func ParseTopology(s string) (Topology, error) {
+	key := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToUpper(s))
+	switch key {
+	case "", "TOROIDAL", "TORUS", "WRAP":
+		return Toroidal, nil
+	case "BOUNDED", "PLANE", "FINITE":
+		return Bounded, nil
+	case "KLEIN", "KLEINBOTTLE":
+		return KleinBottle, nil
+	case "PROJECTIVE", "PROJECTIVEPLANE":
+		return ProjectivePlane, nil
+	}
+	return Toroidal, fmt.Errorf("unknown edge mode %q; expected TOROIDAL, BOUNDED, KLEIN or PROJECTIVE", s)
+}
+
+// Resolve maps (x, y) onto a cell of a cols x rows board. ok is false when the
+// coordinate lies outside a Bounded board. Boards with their own storage use
+// it to share the edge rules of NewBoard.
+func (t Topology) Resolve(x, y, cols, rows int) (col, row int, ok bool) {
+	if x >= 0 && x < cols && y >= 0 && y < rows {
+		return x, y, true
+	}
+
+	switch t {
+	case Bounded:
+		return 0, 0, false
+	case KleinBottle:
+		// Each trip across the left/right seam flips the board upside down.
+		if floorDiv(x, cols)%2 != 0 {
+			y = rows - 1 - y
+		}
+		return mod(x, cols), mod(y, rows), true
+	case ProjectivePlane:
+		// Both seams are glued with a twist.
+		if floorDiv(x, cols)%2 != 0 {
+			y = rows - 1 - y
+		}
+		col = mod(x, cols)
+		if floorDiv(y, rows)%2 != 0 {
+			col = cols - 1 - col
+		}
+		return col, mod(y, rows), true
+	default:
+		return mod(x, cols), mod(y, rows), true
+	}
+}
+
+func mod(a, n int) int {
+	m := a % n
+	if m < 0 {
+		m += n
+	}
+	return m
+}
+
+func floorDiv(a, n int) int {
+	q := a / n
+	if a%n != 0 && a < 0 {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,261 @@
+package ddd
+
+import "slices"
+
+// DefaultUndoLimit is how many commands an UndoStack keeps when created
+// without a limit.
+const DefaultUndoLimit = 100
+
+// Edit is one cell of a grid changing from Before to After.
+type Edit[T any] struct {
+	X, Y          int
+	Before, After T
+}
+
+// UndoStack records edits to a grid as commands that can be undone and
+// redone. Edits recorded between Begin and End form one command, so a whole
+// drag gesture or paste is undone at once; an edit recorded outside a group
+// is a command of its own. Recording a new edit forgets everything that could
+// be redone.
+//
+// Each command carries the tag it was made under, such as the generation of
+// a running simulation. Once the tag has moved on, a command can no longer
+// be undone in place: the caller puts the grid back to how it was under the
+// command's tag first, as Rewind reports.
+type UndoStack[T comparable] interface {
+	// Begin opens a group. Groups nest; the command closes with the
+	// outermost End.
+	Begin()
+	End()
+	// Record notes that the cell at (x, y) changed from before to after. A
+	// cell edited twice in one command keeps its first before and last after;
+	// edits that change nothing are dropped.
+	Record(x, y int, before, after T)
+	// Undo writes the cells of the last command back to their values before
+	// it, onto g. It closes any open group first and reports whether there
+	// was anything to undo. A command that stayed open while the tag changed
+	// only reverts the edits made under its first tag, which is all a grid
+	// rewound to that tag holds of it.
+	Undo(g Grid[T]) bool
+	// Redo writes the cells of the last undone command forward again.
+	Redo(g Grid[T]) bool
+	CanUndo() bool
+	CanRedo() bool
+	// SetTag tags the commands made from now on. Changing the tag forgets
+	// everything that could be redone, as a new edit does; a group still
+	// open stays one command under the tag of its first edit.
+	SetTag(tag int64)
+	// Rewind reports whether the grid must be put back to how it was under
+	// tag before the next Undo: the last command was made under an earlier
+	// tag, or stayed open while the tag changed. It closes any open group
+	// first.
+	Rewind() (tag int64, ok bool)
+	// OldestTag returns the tag of the oldest command still recorded,
+	// including an open group, so a caller can forget whatever it kept to
+	// rewind to older tags.
+	OldestTag() (tag int64, ok bool)
+	// Clear forgets every command. A group still open stays open, emptied,
+	// so the rest of a gesture that spans a Clear is still one command.
+	Clear()
+}
+
+// command is one undoable step: its edits in the order they were made, and
+// where each cell's edit is, to merge repeated edits of a cell. tag is the
+// tag of its first edit; when the tag changed while the command was open,
+// spans is set and first holds its edits as they stood then.
+type command[T comparable] struct {
+	edits []Edit[T]
+	index map[[2]int]int
+	tag   int64
+	spans bool
+	first []Edit[T]
+}
+
+type undoStack[T comparable] struct {
+	limit  int
+	done   []*command[T]
+	undone []*command[T]
+	open   *command[T]
+	depth  int
+	tag    int64
+}
+
+var _ UndoStack[bool] = (*undoStack[bool])(nil)
+
+// NewUndoStack returns an empty stack keeping the last limit commands, or
+// DefaultUndoLimit when limit is not positive.
+func NewUndoStack[T comparable](limit int) UndoStack[T] {
+	if limit <= 0 {
+		limit = DefaultUndoLimit
+	}
+	return &undoStack[T]{limit: limit}
+}
+
+func (s *undoStack[T]) Begin() {
+	if s.depth == 0 {
+		s.open = &command[T]{index: make(map[[2]int]int), tag: s.tag}
+	}
+	s.depth++
+}
+
+func (s *undoStack[T]) End() {
+	if s.depth == 0 {
+		return
+	}
+	s.depth--
+	if s.depth > 0 {
+		return
+	}
+	c := s.open
+	s.open = nil
+	// Drop cells that ended where they started.
+	c.edits, c.first, c.index = changed(c.edits), changed(c.first), nil
+	if len(c.edits) == 0 {
+		return
+	}
+	s.done = append(s.done, c)
+	if len(s.done) > s.limit {
+		s.done = s.done[len(s.done)-s.limit:]
+	}
+}
+
+func (s *undoStack[T]) Record(x, y int, before, after T) {
+	if before == after {
+		return
+	}
+	if s.depth == 0 {
+		s.Begin()
+		defer s.End()
+	}
+	s.undone = nil
+	key := [2]int{x, y}
+	if i, ok := s.open.index[key]; ok {
+		s.open.edits[i].After = after
+		return
+	}
+	s.open.index[key] = len(s.open.edits)
+	s.open.edits = append(s.open.edits, Edit[T]{X: x, Y: y, Before: before, After: after})
+}
+
+func (s *undoStack[T]) Undo(g Grid[T]) bool {
+	s.closeAll()
+	if len(s.done) == 0 {
+		return false
+	}
+	c := s.done[len(s.done)-1]
+	s.done = s.done[:len(s.done)-1]
+	if c.spans {
+		// The grid was rewound to the command's first tag; what it did
+		// after that is gone with the later generations.
+		c.edits, c.first, c.spans = c.first, nil, false
+	}
+	for i := len(c.edits) - 1; i >= 0; i-- {
+		e := c.edits[i]
+		g.SetCoordinate(e.X, e.Y, e.Before)
+	}
+	s.undone = append(s.undone, c)
+	return true
+}
+
+func (s *undoStack[T]) Redo(g Grid[T]) bool {
+	s.closeAll()
+	if len(s.undone) == 0 {
+		return false
+	}
+	c := s.undone[len(s.undone)-1]
+	s.undone = s.undone[:len(s.undone)-1]
+	for _, e := range c.edits {
+		g.SetCoordinate(e.X, e.Y, e.After)
+	}
+	s.done = append(s.done, c)
+	return true
+}
+
+func (s *undoStack[T]) CanUndo() bool {
+	return len(s.done) > 0 || (s.open != nil && len(s.open.edits) > 0)
+}
+
+func (s *undoStack[T]) CanRedo() bool {
+	return len(s.undone) > 0
+}
+
+func (s *undoStack[T]) SetTag(tag int64) {
+	if tag == s.tag {
+		return
+	}
+	s.tag = tag
+	s.undone = nil
+	switch c := s.open; {
+	case c == nil:
+	case len(c.edits) == 0:
+		c.tag = tag
+	case !c.spans:
+		c.spans, c.first = true, slices.Clone(c.edits)
+	}
+}
+
+func (s *undoStack[T]) Rewind() (int64, bool) {
+	s.closeAll()
+	if len(s.done) == 0 {
+		return 0, false
+	}
+	c := s.done[len(s.done)-1]
+	return c.tag, c.tag != s.tag || c.spans
+}
+
+func (s *undoStack[T]) OldestTag() (int64, bool) {
+	switch {
+	case len(s.done) > 0:
+		return s.done[0].tag, true
+	case s.open != nil:
+		return s.open.tag, true
+	}
+	return 0, false
+}
+
+func (s *undoStack[T]) Clear() {
+	s.done, s.undone = nil, nil
+	if s.open != nil {
+		s.open = &command[T]{index: make(map[[2]int]int), tag: s.tag}
+	}
+}
+
+// changed returns the edits that change their cell, reusing edits.
+func changed[T comparable](edits []Edit[T]) []Edit[T] {
+	kept := edits[:0]
+	for _, e := range edits {
+		if e.Before != e.After {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// closeAll ends any open group, however deeply nested.
+func (s *undoStack[T]) closeAll() {
+	for s.depth > 0 {
+		s.End()
+	}
+}
+
+// recorded is a Grid whose writes are recorded on an UndoStack.
+type recorded[T comparable] struct {
+	Grid[T]
+	stack UndoStack[T]
+}
+
+// Recorded returns a view of g whose writes are recorded on s, so SetCoordinate
+// calls and region helpers such as Stamp become undoable. Writes that fail,
+// such as past the edge of a Bounded board, are not recorded.
+func Recorded[T comparable](g Grid[T], s UndoStack[T]) Grid[T] {
+	return recorded[T]{Grid: g, stack: s}
+}
+
+func (r recorded[T]) SetCoordinate(x int, y int, value T) error {
+	before := r.Grid.Coordinate(x, y)
+	if err := r.Grid.SetCoordinate(x, y, value); err != nil {
+		return err
+	}
+	r.stack.Record(x, y, before, value)
+	return nil
+}
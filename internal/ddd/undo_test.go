@@ -0,0 +1,183 @@
+package ddd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUndoStack_UndoAndRedo(t *testing.T) {
+	b := newBoard[int](3, 2)
+	s := NewUndoStack[int](0)
+	g := Recorded[int](b, s)
+
+	g.SetCoordinate(0, 0, 1)
+	g.SetCoordinate(1, 0, 2)
+	if !s.Undo(b) || !slices.Equal(b.FlatSlice(), []int{1, 0, 0, 0, 0, 0}) {
+		t.Errorf("Expected the last edit undone, but got %v", b.FlatSlice())
+	}
+	if !s.Undo(b) || !slices.Equal(b.FlatSlice(), make([]int, 6)) {
+		t.Errorf("Expected both edits undone, but got %v", b.FlatSlice())
+	}
+	if s.Undo(b) || s.CanUndo() {
+		t.Error("Expected nothing left to undo")
+	}
+	if !s.Redo(b) || !s.Redo(b) || !slices.Equal(b.FlatSlice(), []int{1, 2, 0, 0, 0, 0}) {
+		t.Errorf("Expected both edits redone, but got %v", b.FlatSlice())
+	}
+	if s.Redo(b) || s.CanRedo() {
+		t.Error("Expected nothing left to redo")
+	}
+}
+
+func TestUndoStack_GroupsAGesture(t *testing.T) {
+	b := newBoard[int](3, 2)
+	s := NewUndoStack[int](0)
+	g := Recorded[int](b, s)
+
+	s.Begin()
+	g.SetCoordinate(0, 0, 1)
+	g.SetCoordinate(1, 1, 1)
+	// Dragging back over a cell keeps its original before value.
+	g.SetCoordinate(0, 0, 2)
+	s.Begin()
+	g.SetCoordinate(2, 0, 3)
+	s.End()
+	s.End()
+
+	s.Undo(b)
+	if !slices.Equal(b.FlatSlice(), make([]int, 6)) {
+		t.Errorf("Expected the whole gesture undone at once, but got %v", b.FlatSlice())
+	}
+	s.Redo(b)
+	if !slices.Equal(b.FlatSlice(), []int{2, 0, 3, 0, 1, 0}) {
+		t.Errorf("Expected the whole gesture redone, but got %v", b.FlatSlice())
+	}
+}
+
+func TestUndoStack_ClearDuringAGesture(t *testing.T) {
+	b := newBoard[int](3, 1)
+	s := NewUndoStack[int](0)
+	g := Recorded[int](b, s)
+
+	// The board is reset while the brush is held down.
+	s.Begin()
+	g.SetCoordinate(0, 0, 1)
+	s.Clear()
+	g.SetCoordinate(1, 0, 1)
+	g.SetCoordinate(2, 0, 1)
+	s.End()
+
+	if !s.Undo(b) {
+		t.Fatal("Expected the rest of the gesture to be undoable")
+	}
+	if !slices.Equal(b.FlatSlice(), []int{1, 0, 0}) {
+		t.Errorf("Expected the edits after the clear undone together, but got %v", b.FlatSlice())
+	}
+	if s.CanUndo() {
+		t.Error("Expected the edit before the clear to be forgotten")
+	}
+}
+
+func TestUndoStack_Tags(t *testing.T) {
+	b := newBoard[int](3, 1)
+	s := NewUndoStack[int](0)
+	g := Recorded[int](b, s)
+
+	g.SetCoordinate(0, 0, 1)
+	if _, ok := s.Rewind(); ok {
+		t.Error("Expected an edit under the current tag to be undone in place")
+	}
+	// A generation steps while the brush is held down.
+	s.SetTag(1)
+	s.Begin()
+	g.SetCoordinate(1, 0, 1)
+	s.SetTag(2)
+	g.SetCoordinate(2, 0, 1)
+	s.End()
+
+	if tag, ok := s.Rewind(); !ok || tag != 1 {
+		t.Errorf("Expected a gesture that spans a tag to rewind to 1, but got %d, %v", tag, ok)
+	}
+	s.SetTag(1)
+	s.Undo(b)
+	if !slices.Equal(b.FlatSlice(), []int{1, 0, 1}) {
+		t.Errorf("Expected only the edits made under the first tag undone, but got %v", b.FlatSlice())
+	}
+	if tag, ok := s.Rewind(); !ok || tag != 0 {
+		t.Errorf("Expected the first edit to rewind to 0, but got %d, %v", tag, ok)
+	}
+	if tag, ok := s.OldestTag(); !ok || tag != 0 {
+		t.Errorf("Expected the oldest tag 0, but got %d, %v", tag, ok)
+	}
+	if !s.Redo(b) || !slices.Equal(b.FlatSlice(), []int{1, 1, 1}) {
+		t.Errorf("Expected the gesture redone as far as its first tag, but got %v", b.FlatSlice())
+	}
+	s.Undo(b)
+	s.SetTag(2)
+	if s.CanRedo() {
+		t.Error("Expected a new tag to forget what could be redone")
+	}
+}
+
+func TestUndoStack_DropsNoOps(t *testing.T) {
+	b := newBoard[int](2, 2)
+	s := NewUndoStack[int](0)
+	g := Recorded[int](b, s)
+
+	g.SetCoordinate(0, 0, 0)
+	s.Begin()
+	g.SetCoordinate(1, 1, 5)
+	g.SetCoordinate(1, 1, 0)
+	s.End()
+	if s.CanUndo() {
+		t.Error("Expected edits that change nothing to leave nothing to undo")
+	}
+}
+
+func TestUndoStack_NewEditForgetsRedo(t *testing.T) {
+	b := newBoard[int](2, 2)
+	s := NewUndoStack[int](0)
+	g := Recorded[int](b, s)
+
+	g.SetCoordinate(0, 0, 1)
+	s.Undo(b)
+	g.SetCoordinate(1, 0, 1)
+	if s.CanRedo() {
+		t.Error("Expected a new edit to forget the undone one")
+	}
+}
+
+func TestUndoStack_Limit(t *testing.T) {
+	b := newBoard[int](4, 1)
+	s := NewUndoStack[int](2)
+	g := Recorded[int](b, s)
+
+	for x := range 4 {
+		g.SetCoordinate(x, 0, 1)
+	}
+	for s.Undo(b) {
+	}
+	if !slices.Equal(b.FlatSlice(), []int{1, 1, 0, 0}) {
+		t.Errorf("Expected only the last 2 edits to be undoable, but got %v", b.FlatSlice())
+	}
+}
+
+func TestRecorded_StampAndBounds(t *testing.T) {
+	b := newBoard[int](3, 3, WithTopology[int](Bounded))
+	s := NewUndoStack[int](0)
+	g := Recorded[int](b, s)
+
+	s.Begin()
+	r := &Region[int]{Width: 2, Height: 2, Cells: []int{1, 2, 3, 4}}
+	if err := Stamp(g, r, 2, 2, nil); err == nil {
+		t.Error("Expected stamping past a bounded edge to fail")
+	}
+	s.End()
+	s.Undo(b)
+	if !slices.Equal(b.FlatSlice(), make([]int, 9)) {
+		t.Errorf("Expected the stamp undone, but got %v", b.FlatSlice())
+	}
+	if s.CanUndo() {
+		t.Error("Expected the failed writes not to be recorded")
+	}
+}
@@ -0,0 +1,145 @@
+package menu
+
+import (
+	"SideProjectGames/internal/config"
+	"SideProjectGames/internal/module"
+	"SideProjectGames/internal/scene"
+	"bytes"
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	width      = 640
+	height     = 480
+	itemTop    = 120
+	itemHeight = 56
+)
+
+// Run opens the main menu listing every registered module. Choosing a module
+// starts it in the same window; Escape returns to the menu.
+func Run(cfg config.AppConfig) error {
+	m, err := New(cfg, module.All())
+	if err != nil {
+		return err
+	}
+	return ebiten.RunGame(m.manager)
+}
+
+// Menu is the home scene of a scene.Manager.
+type Menu struct {
+	manager  *scene.Manager
+	cfg      config.AppConfig
+	modules  []module.Module
+	selected int
+	errMsg   string
+	face     *text.GoTextFaceSource
+}
+
+var _ scene.Scene = (*Menu)(nil)
+var _ scene.Windowed = (*Menu)(nil)
+
+// New builds a menu for modules and the manager that hosts it.
+func New(cfg config.AppConfig, modules []module.Module) (*Menu, error) {
+	s, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Menu{cfg: cfg, modules: modules, face: s}
+	m.manager = scene.NewManager(m)
+	return m, nil
+}
+
+func (m *Menu) Title() string {
+	return "Side Project Games"
+}
+
+func (m *Menu) WindowSize() (int, int) {
+	return width, height
+}
+
+func (m *Menu) Update() error {
+	if len(m.modules) == 0 {
+		return nil
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		m.selected = (m.selected + 1) % len(m.modules)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		m.selected = (m.selected - 1 + len(m.modules)) % len(m.modules)
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		_, mouseY := ebiten.CursorPosition()
+		if i := (mouseY - itemTop) / itemHeight; mouseY >= itemTop && i < len(m.modules) {
+			m.selected = i
+			m.start()
+			return nil
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		m.start()
+	}
+	return nil
+}
+
+// start builds a fresh scene for the selected module so every visit starts a new game.
+func (m *Menu) start() {
+	mod := m.modules[m.selected]
+	s, err := mod.NewScene(m.cfg)
+	if err != nil {
+		m.errMsg = fmt.Sprintf("%s: %v", mod.Name(), err)
+		return
+	}
+	m.errMsg = ""
+	m.manager.Switch(s)
+}
+
+func (m *Menu) Draw(screen *ebiten.Image) {
+	screen.Fill(color.RGBA{R: 20, G: 30, B: 40, A: 255})
+
+	white := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	grey := color.RGBA{R: 160, G: 170, B: 180, A: 255}
+	highlight := color.RGBA{R: 60, G: 90, B: 120, A: 255}
+
+	m.drawText(screen, "Side Project Games", 40, 36, 40, white)
+
+	for i, mod := range m.modules {
+		y := itemTop + i*itemHeight
+		if i == m.selected {
+			vector.DrawFilledRect(screen, 30, float32(y), width-60, itemHeight-6, highlight, false)
+		}
+		m.drawText(screen, mod.Name(), 24, 44, float64(y+4), white)
+		m.drawText(screen, mod.Description(), 16, 44, float64(y+30), grey)
+	}
+
+	if len(m.modules) == 0 {
+		m.drawText(screen, "No modules registered", 20, 40, itemTop, grey)
+	}
+
+	m.drawText(screen, "Up/Down + Enter or click to play. Esc returns to this menu.", 16, 40, height-40, grey)
+
+	if m.errMsg != "" {
+		m.drawText(screen, m.errMsg, 16, 40, height-70, color.RGBA{R: 255, G: 80, B: 80, A: 255})
+	}
+}
+
+func (m *Menu) drawText(screen *ebiten.Image, msg string, size float64, x, y float64, c color.Color) {
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(c)
+	text.Draw(screen, msg, &text.GoTextFace{Source: m.face, Size: size}, op)
+}
+
+func (m *Menu) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return outsideWidth, outsideHeight
+}
@@ -0,0 +1,106 @@
+package module
+
+import (
+	"SideProjectGames/internal/config"
+	"SideProjectGames/internal/scene"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Module is a self-contained game that can be launched by name. Run owns a
+// whole Ebiten loop, while NewScene builds the game so the main menu can host it.
+type Module interface {
+	Name() string
+	Description() string
+	Run(cfg config.AppConfig) error
+	NewScene(cfg config.AppConfig) (scene.Scene, error)
+}
+
+type registry struct {
+	mu      sync.RWMutex
+	modules map[string]Module
+}
+
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+	return &registry{modules: make(map[string]Module)}
+}
+
+// Register makes a module available under its (case-insensitive) name.
+// It is meant to be called from a module's init function and panics if the
+// name is empty or already taken, mirroring database/sql.Register.
+func Register(m Module) {
+	defaultRegistry.register(m)
+}
+
+// Lookup returns the module registered under name. The error lists every
+// available module so a bad MODULE value is easy to fix.
+func Lookup(name string) (Module, error) {
+	return defaultRegistry.lookup(name)
+}
+
+// Names returns the registered module names in sorted order.
+func Names() []string {
+	return defaultRegistry.names()
+}
+
+// All returns the registered modules sorted by name.
+func All() []Module {
+	return defaultRegistry.all()
+}
+
+func (r *registry) register(m Module) {
+	if m == nil {
+		panic("module: Register module is nil")
+	}
+	name := normalize(m.Name())
+	if name == "" {
+		panic("module: Register module has an empty name")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, dup := r.modules[name]; dup {
+		panic("module: Register called twice for module " + name)
+	}
+	r.modules[name] = m
+}
+
+func (r *registry) lookup(name string) (Module, error) {
+	r.mu.RLock()
+	m, ok := r.modules[normalize(name)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown module %q; available modules: %s", name, strings.Join(r.names(), ", "))
+	}
+	return m, nil
+}
+
+func (r *registry) names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.modules))
+	for name := range r.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *registry) all() []Module {
+	names := r.names()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	modules := make([]Module, 0, len(names))
+	for _, name := range names {
+		modules = append(modules, r.modules[name])
+	}
+	return modules
+}
+
+func normalize(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
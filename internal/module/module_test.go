@@ -0,0 +1,69 @@
+package module
+
+import (
+	"SideProjectGames/internal/config"
+	"SideProjectGames/internal/scene"
+	"strings"
+	"testing"
+)
+
+type fakeModule struct {
+	name string
+}
+
+func (f fakeModule) Name() string                   { return f.name }
+func (f fakeModule) Description() string            { return "fake " + f.name }
+func (f fakeModule) Run(cfg config.AppConfig) error { return nil }
+func (f fakeModule) NewScene(cfg config.AppConfig) (scene.Scene, error) {
+	return nil, nil
+}
+
+func TestRegistry_LookupIsCaseInsensitive(t *testing.T) {
+	r := newRegistry()
+	r.register(fakeModule{name: "GOL"})
+
+	m, err := r.lookup("gol")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if m.Name() != "GOL" {
+		t.Errorf("Expected module GOL, but got %s", m.Name())
+	}
+}
+
+func TestRegistry_UnknownModuleListsAvailable(t *testing.T) {
+	r := newRegistry()
+	r.register(fakeModule{name: "GOL"})
+	r.register(fakeModule{name: "BATTLESHIP"})
+
+	_, err := r.lookup("CHESS")
+	if err == nil {
+		t.Fatal("Expected an error for an unknown module, but got nil")
+	}
+	if !strings.Contains(err.Error(), "BATTLESHIP, GOL") {
+		t.Errorf("Expected error to list the available modules, but got %q", err.Error())
+	}
+}
+
+func TestRegistry_DuplicatePanics(t *testing.T) {
+	r := newRegistry()
+	r.register(fakeModule{name: "GOL"})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a duplicate name to panic")
+		}
+	}()
+	r.register(fakeModule{name: "gol"})
+}
+
+func TestRegistry_AllSortedByName(t *testing.T) {
+	r := newRegistry()
+	r.register(fakeModule{name: "ZETA"})
+	r.register(fakeModule{name: "ALPHA"})
+
+	all := r.all()
+	if len(all) != 2 || all[0].Name() != "ALPHA" || all[1].Name() != "ZETA" {
+		t.Errorf("Expected modules sorted by name, but got %v", all)
+	}
+}
@@ -0,0 +1,78 @@
+// Package rng is the random number service shared by every module. All
+// randomness in a session comes from one seed, normally the SEED config
+// value, so any board, soup or AI move can be reproduced by running again
+// with the seed the game shows.
+package rng
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source is a seeded stream of random numbers. It is safe for concurrent use.
+type Source interface {
+	// Seed is the seed the stream was created from.
+	Seed() int64
+	// Intn returns a number in [0, n); it panics if n <= 0.
+	Intn(n int) int
+	// Float64 returns a number in [0, 1).
+	Float64() float64
+	Uint64() uint64
+	// Derive returns an independent stream for name, seeded from this
+	// stream's seed and name only, so what one part of a game draws does not
+	// shift the numbers another part sees.
+	Derive(name string) Source
+}
+
+type source struct {
+	seed int64
+	mu   sync.Mutex
+	r    *rand.Rand
+}
+
+var _ Source = (*source)(nil)
+
+// New returns the stream for seed. The same seed always gives the same
+// numbers.
+func New(seed int64) Source {
+	return &source{seed: seed, r: rand.New(rand.NewSource(seed))}
+}
+
+// NewSeed picks a seed from the clock, for when none was configured. It is
+// never zero, so zero can stand for "not configured".
+func NewSeed() int64 {
+	if seed := time.Now().UnixNano(); seed != 0 {
+		return seed
+	}
+	return 1
+}
+
+func (s *source) Seed() int64 {
+	return s.seed
+}
+
+func (s *source) Intn(n int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Intn(n)
+}
+
+func (s *source) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Float64()
+}
+
+func (s *source) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.r.Uint64()
+}
+
+func (s *source) Derive(name string) Source {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return New(s.seed ^ int64(h.Sum64()))
+}
@@ -0,0 +1,47 @@
+package rng
+
+import (
+	"slices"
+	"testing"
+)
+
+func draw(s Source, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = s.Intn(1000)
+	}
+	return out
+}
+
+func TestNew_IsReproducible(t *testing.T) {
+	if !slices.Equal(draw(New(42), 20), draw(New(42), 20)) {
+		t.Error("Expected the same seed to give the same numbers")
+	}
+	if slices.Equal(draw(New(42), 20), draw(New(43), 20)) {
+		t.Error("Expected different seeds to give different numbers")
+	}
+	if got := New(42).Seed(); got != 42 {
+		t.Errorf("Expected seed 42, but got %d", got)
+	}
+}
+
+func TestDerive_IsIndependentOfDraws(t *testing.T) {
+	a, b := New(7), New(7)
+	draw(a, 100)
+
+	if !slices.Equal(draw(a.Derive("GOL"), 20), draw(b.Derive("GOL"), 20)) {
+		t.Error("Expected a derived stream to ignore what its parent has drawn")
+	}
+	if slices.Equal(draw(b.Derive("GOL"), 20), draw(b.Derive("AI"), 20)) {
+		t.Error("Expected differently named streams to differ")
+	}
+	if slices.Equal(draw(New(7).Derive("GOL"), 20), draw(New(8).Derive("GOL"), 20)) {
+		t.Error("Expected a derived stream to depend on the parent seed")
+	}
+}
+
+func TestNewSeed_IsNotZero(t *testing.T) {
+	if NewSeed() == 0 {
+		t.Error("Expected a non-zero seed")
+	}
+}
@@ -0,0 +1,95 @@
+package scene
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Scene is one screen hosted by a Manager. Its methods mirror ebiten.Game so
+// a scene can be driven exactly like a top-level game.
+type Scene interface {
+	Update() error
+	Draw(screen *ebiten.Image)
+	Layout(outsideWidth, outsideHeight int) (int, int)
+}
+
+// Windowed is implemented by scenes that want a specific window title and size
+// while they are active.
+type Windowed interface {
+	Title() string
+	WindowSize() (width, height int)
+}
+
+// Resizable is implemented by scenes that can draw at any window size. Other
+// scenes get a fixed-size window.
+type Resizable interface {
+	Resizable() bool
+}
+
+// Manager hosts a single Ebiten loop and switches between scenes. When it has
+// a home scene (the main menu), pressing Escape in any other scene returns there.
+type Manager struct {
+	home    Scene
+	current Scene
+}
+
+var _ ebiten.Game = (*Manager)(nil)
+
+// NewManager creates a manager that starts on home. home may be nil, in which
+// case Switch must be called before the loop starts and Escape is left to the scene.
+func NewManager(home Scene) *Manager {
+	m := &Manager{home: home}
+	if home != nil {
+		m.Switch(home)
+	}
+	return m
+}
+
+// Run hosts a single scene in its own Ebiten loop, without a menu to return to.
+func Run(s Scene) error {
+	m := NewManager(nil)
+	m.Switch(s)
+	return ebiten.RunGame(m)
+}
+
+// Switch makes s the active scene and applies its window settings.
+func (m *Manager) Switch(s Scene) {
+	m.current = s
+	if w, ok := s.(Windowed); ok {
+		ebiten.SetWindowTitle(w.Title())
+		ebiten.SetWindowSize(w.WindowSize())
+	}
+	mode := ebiten.WindowResizingModeDisabled
+	if r, ok := s.(Resizable); ok && r.Resizable() {
+		mode = ebiten.WindowResizingModeEnabled
+	}
+	ebiten.SetWindowResizingMode(mode)
+}
+
+// Home returns to the home scene, if there is one.
+func (m *Manager) Home() {
+	if m.home != nil {
+		m.Switch(m.home)
+	}
+}
+
+// Current returns the active scene.
+func (m *Manager) Current() Scene {
+	return m.current
+}
+
+func (m *Manager) Update() error {
+	if m.home != nil && m.current != m.home && inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		m.Home()
+		return nil
+	}
+	return m.current.Update()
+}
+
+func (m *Manager) Draw(screen *ebiten.Image) {
+	m.current.Draw(screen)
+}
+
+func (m *Manager) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return m.current.Layout(outsideWidth, outsideHeight)
+}